@@ -0,0 +1,528 @@
+// Package anim provides a declarative format for describing Tweens and Sequences
+// in data files, so that animations can be authored in JSON or YAML rather than
+// Go. Each file holds a set of named animations:
+//
+//	{
+//	  "fade": {"begin": 1, "end": 0, "duration": 0.5, "easing": "OutQuad"},
+//	  "bounce": {
+//	    "loop": -1,
+//	    "yoyo": true,
+//	    "sequence": [
+//	      {"begin": 0, "end": 300, "duration": 1, "easing": "OutBounce"},
+//	      {"delay": 0.5},
+//	      {"by": -50, "duration": 0.25}
+//	    ]
+//	  }
+//	}
+//
+// A definition with a sequence describes a Sequence, one with a delay describes a
+// pause holding the current value, and anything else describes a Tween. Easing functions
+// are referred to by the names used in the ease package and default to Linear.
+package anim
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/tanema/gween"
+	"github.com/tanema/gween/ease"
+)
+
+// Definition describes a single Tween, delay or Sequence.
+type Definition struct {
+	ID   string   `json:"id,omitempty" yaml:"id,omitempty"`
+	Tags []string `json:"tags,omitempty" yaml:"tags,omitempty"`
+
+	// Begin and End are the values of an absolute Tween.
+	Begin *float32 `json:"begin,omitempty" yaml:"begin,omitempty"`
+	End   *float32 `json:"end,omitempty" yaml:"end,omitempty"`
+	// By is the delta of a Tween created with gween.NewBy.
+	By *float32 `json:"by,omitempty" yaml:"by,omitempty"`
+	// From is the starting value of a Tween created with gween.NewFrom.
+	From     *float32 `json:"from,omitempty" yaml:"from,omitempty"`
+	Duration float32  `json:"duration,omitempty" yaml:"duration,omitempty"`
+	Easing   string   `json:"easing,omitempty" yaml:"easing,omitempty"`
+	// BackEasing is the easing used on the way back of a ping-pong.
+	BackEasing string `json:"backEasing,omitempty" yaml:"backEasing,omitempty"`
+
+	// Delay describes a pause, holding the current value.
+	Delay *float32 `json:"delay,omitempty" yaml:"delay,omitempty"`
+
+	// Sequence holds the steps of a Sequence, executed one after the other.
+	Sequence []*Definition `json:"sequence,omitempty" yaml:"sequence,omitempty"`
+	// Reverse starts a Tween or Sequence from its end, playing backward. Steps
+	// play in the direction of their Sequence.
+	Reverse bool `json:"reverse,omitempty" yaml:"reverse,omitempty"`
+
+	// Loop, Yoyo and Mode describe how a Tween or Sequence loops. Mode is the
+	// name of a gween.LoopMode, and yoyo is short for the "yoyo" mode.
+	Loop int    `json:"loop,omitempty" yaml:"loop,omitempty"`
+	Yoyo bool   `json:"yoyo,omitempty" yaml:"yoyo,omitempty"`
+	Mode string `json:"mode,omitempty" yaml:"mode,omitempty"`
+}
+
+// loopModes are the loop modes that can be referred to by name.
+var loopModes = []gween.LoopMode{
+	gween.LoopRestart,
+	gween.LoopYoyo,
+	gween.LoopIncremental,
+	gween.LoopPingPong,
+	gween.LoopShuffle,
+}
+
+// Error is returned when a Definition is invalid. Path points at the offending
+// definition, for example "intro.sequence[2].easing".
+type Error struct {
+	Path string
+	Msg  string
+}
+
+func (err *Error) Error() string {
+	return fmt.Sprintf("%s: %s", err.Path, err.Msg)
+}
+
+// LoadJSON reads a set of named definitions in JSON and builds their animations.
+// Definitions that cannot be decoded are reported as an *Error.
+func LoadJSON(r io.Reader) (map[string]gween.Animation, error) {
+	var nodes map[string]json.RawMessage
+	if err := json.NewDecoder(r).Decode(&nodes); err != nil {
+		return nil, err
+	}
+	defs := make(map[string]*Definition, len(nodes))
+	for name := range nodes {
+		defs[name] = nil
+	}
+	for _, name := range sortedNames(defs) {
+		def, err := decode(nodes[name], name)
+		if err != nil {
+			return nil, err
+		}
+		defs[name] = def
+	}
+	return Build(defs)
+}
+
+// LoadYAML reads a set of named definitions in YAML and builds their animations.
+// Definitions that cannot be decoded are reported as an *Error.
+func LoadYAML(r io.Reader) (map[string]gween.Animation, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	var nodes map[string]interface{}
+	if err := yaml.UnmarshalStrict(data, &nodes); err != nil {
+		return nil, err
+	}
+	defs := make(map[string]*Definition, len(nodes))
+	for name := range nodes {
+		defs[name] = nil
+	}
+	for _, name := range sortedNames(defs) {
+		// Definitions are decoded as JSON, which YAML is a superset of
+		node, err := jsonValue(nodes[name], name)
+		if err != nil {
+			return nil, err
+		}
+		data, err := json.Marshal(node)
+		if err != nil {
+			return nil, &Error{Path: name, Msg: err.Error()}
+		}
+		if defs[name], err = decode(data, name); err != nil {
+			return nil, err
+		}
+	}
+	return Build(defs)
+}
+
+// node is a Definition whose steps are decoded one at a time, so that decoding
+// errors can point at the offending step.
+type node struct {
+	*Definition
+	Sequence []json.RawMessage `json:"sequence,omitempty"`
+}
+
+// decode decodes the JSON definition at path, which is nil for null.
+func decode(data json.RawMessage, path string) (*Definition, error) {
+	if string(data) == "null" {
+		return nil, nil
+	}
+	n := node{Definition: &Definition{}}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&n); err != nil {
+		if typeErr, ok := err.(*json.UnmarshalTypeError); ok && typeErr.Field != "" {
+			return nil, &Error{Path: path + "." + typeErr.Field, Msg: fmt.Sprintf("cannot use %s as %v", typeErr.Value, typeErr.Type)}
+		}
+		return nil, &Error{Path: path, Msg: strings.TrimPrefix(err.Error(), "json: ")}
+	}
+	if n.Sequence != nil {
+		n.Definition.Sequence = make([]*Definition, len(n.Sequence))
+		for i, step := range n.Sequence {
+			var err error
+			if n.Definition.Sequence[i], err = decode(step, fmt.Sprintf("%s.sequence[%d]", path, i)); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return n.Definition, nil
+}
+
+// jsonValue converts a value decoded from YAML at path into one that can be
+// encoded as JSON.
+func jsonValue(value interface{}, path string) (interface{}, error) {
+	switch value := value.(type) {
+	case map[interface{}]interface{}:
+		object := make(map[string]interface{}, len(value))
+		for key, field := range value {
+			name, ok := key.(string)
+			if !ok {
+				return nil, &Error{Path: path, Msg: fmt.Sprintf("invalid field name %v", key)}
+			}
+			var err error
+			if object[name], err = jsonValue(field, path+"."+name); err != nil {
+				return nil, err
+			}
+		}
+		return object, nil
+	case []interface{}:
+		array := make([]interface{}, len(value))
+		for i, element := range value {
+			var err error
+			if array[i], err = jsonValue(element, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return nil, err
+			}
+		}
+		return array, nil
+	}
+	return value, nil
+}
+
+// SaveJSON writes the definitions of a set of named animations as JSON.
+func SaveJSON(w io.Writer, animations map[string]gween.Animation) error {
+	defs, err := DescribeAll(animations)
+	if err != nil {
+		return err
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(defs)
+}
+
+// SaveYAML writes the definitions of a set of named animations as YAML.
+func SaveYAML(w io.Writer, animations map[string]gween.Animation) error {
+	defs, err := DescribeAll(animations)
+	if err != nil {
+		return err
+	}
+	data, err := yaml.Marshal(defs)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// Build builds the animations of a set of named definitions. Every definition is
+// validated, and the first invalid one is reported as an *Error.
+func Build(defs map[string]*Definition) (map[string]gween.Animation, error) {
+	animations := make(map[string]gween.Animation, len(defs))
+	for _, name := range sortedNames(defs) {
+		animation, err := defs[name].Build(name)
+		if err != nil {
+			return nil, err
+		}
+		animations[name] = animation
+	}
+	return animations, nil
+}
+
+// Build builds the Tween or Sequence described by the definition. The path is
+// used to point at the offending definition in any *Error returned.
+func (def *Definition) Build(path string) (gween.Animation, error) {
+	if def == nil {
+		return nil, &Error{Path: path, Msg: "missing definition"}
+	}
+	if def.Sequence == nil {
+		var tween *gween.Tween
+		var err error
+		if def.Delay != nil {
+			tween, err = def.buildDelay(path)
+		} else {
+			tween, err = def.buildTween(path)
+		}
+		if err != nil {
+			// A nil *gween.Tween would make a non-nil Animation
+			return nil, err
+		}
+		return tween, nil
+	}
+	if def.Begin != nil || def.End != nil || def.By != nil || def.From != nil || def.Delay != nil ||
+		def.Duration != 0 || def.Easing != "" || def.BackEasing != "" {
+		return nil, &Error{Path: path, Msg: "a sequence cannot also describe a tween"}
+	}
+	if len(def.Sequence) == 0 {
+		return nil, &Error{Path: path + ".sequence", Msg: "a sequence needs at least one step"}
+	}
+	mode, err := def.loopMode(path)
+	if err != nil {
+		return nil, err
+	}
+
+	seq := gween.NewSequence()
+	for i, step := range def.Sequence {
+		stepPath := fmt.Sprintf("%s.sequence[%d]", path, i)
+		tween, err := step.buildStep(stepPath)
+		if err != nil {
+			return nil, err
+		}
+		seq.Add(tween)
+	}
+	if def.Loop != 0 {
+		seq.SetLoop(def.Loop)
+	}
+	seq.SetLoopMode(mode)
+	if def.Reverse {
+		if seq.Yoyo() {
+			return nil, &Error{Path: path + ".reverse", Msg: "a yoyo sequence always starts forward"}
+		}
+		// Starts from the end of the last tween
+		seq.PlayBackward()
+		seq.Rewind()
+	}
+	seq.SetID(def.ID)
+	seq.Tag(def.Tags...)
+	return seq, nil
+}
+
+// buildStep builds a single step of a Sequence.
+func (def *Definition) buildStep(path string) (*gween.Tween, error) {
+	if def == nil {
+		return nil, &Error{Path: path, Msg: "missing definition"}
+	}
+	if def.Sequence != nil {
+		return nil, &Error{Path: path, Msg: "sequences cannot be nested"}
+	}
+	if def.Delay != nil {
+		return def.buildDelay(path)
+	}
+	if def.Reverse {
+		return nil, &Error{Path: path + ".reverse", Msg: "steps play in the direction of their sequence"}
+	}
+	return def.buildTween(path)
+}
+
+// buildDelay builds a pause.
+func (def *Definition) buildDelay(path string) (*gween.Tween, error) {
+	if def.Begin != nil || def.End != nil || def.By != nil || def.From != nil ||
+		def.Duration != 0 || def.Easing != "" || def.BackEasing != "" ||
+		def.Loop != 0 || def.Yoyo || def.Mode != "" || def.Reverse {
+		return nil, &Error{Path: path, Msg: "a delay cannot also describe a tween"}
+	}
+	if *def.Delay < 0 {
+		return nil, &Error{Path: path + ".delay", Msg: fmt.Sprintf("invalid delay %v", *def.Delay)}
+	}
+	tween := gween.NewDelay(*def.Delay)
+	tween.SetID(def.ID)
+	tween.Tag(def.Tags...)
+	return tween, nil
+}
+
+// buildTween builds a single Tween.
+func (def *Definition) buildTween(path string) (*gween.Tween, error) {
+	easing := ease.Linear
+	if def.Easing != "" {
+		var ok bool
+		if easing, ok = ease.ByName(def.Easing); !ok {
+			return nil, &Error{Path: path + ".easing", Msg: fmt.Sprintf("unknown easing %q", def.Easing)}
+		}
+	}
+	var backEasing ease.TweenFunc
+	if def.BackEasing != "" {
+		var ok bool
+		if backEasing, ok = ease.ByName(def.BackEasing); !ok {
+			return nil, &Error{Path: path + ".backEasing", Msg: fmt.Sprintf("unknown easing %q", def.BackEasing)}
+		}
+	}
+	if def.Duration < 0 {
+		return nil, &Error{Path: path + ".duration", Msg: fmt.Sprintf("invalid duration %v", def.Duration)}
+	}
+	mode, err := def.loopMode(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var tween *gween.Tween
+	switch {
+	case def.By != nil:
+		if def.Begin != nil || def.End != nil || def.From != nil {
+			return nil, &Error{Path: path + ".by", Msg: "by cannot be combined with begin, end or from"}
+		}
+		tween = gween.NewBy(*def.By, def.Duration, easing)
+	case def.From != nil:
+		if def.Begin != nil || def.End != nil {
+			return nil, &Error{Path: path + ".from", Msg: "from cannot be combined with begin or end"}
+		}
+		tween = gween.NewFrom(*def.From, def.Duration, easing)
+	case def.Begin == nil:
+		return nil, &Error{Path: path, Msg: "a tween needs begin and end, by or from"}
+	case def.End == nil:
+		return nil, &Error{Path: path + ".begin", Msg: "begin needs an end"}
+	default:
+		tween = gween.New(*def.Begin, *def.End, def.Duration, easing)
+	}
+	if def.Loop != 0 {
+		tween.SetLoop(def.Loop)
+	}
+	tween.SetLoopMode(mode)
+	tween.SetBackEasing(backEasing)
+	if def.Reverse {
+		// Starts from the end
+		tween.PlayBackward()
+		tween.Rewind()
+	}
+	tween.SetID(def.ID)
+	tween.Tag(def.Tags...)
+	return tween, nil
+}
+
+// loopMode returns the loop mode described by the definition, checking its loop
+// count on the way.
+func (def *Definition) loopMode(path string) (gween.LoopMode, error) {
+	if def.Loop < -1 {
+		return 0, &Error{Path: path + ".loop", Msg: fmt.Sprintf("invalid loop count %d", def.Loop)}
+	}
+	mode, found := gween.LoopRestart, def.Mode == ""
+	for _, m := range loopModes {
+		if m.String() == def.Mode {
+			mode, found = m, true
+		}
+	}
+	if !found {
+		return 0, &Error{Path: path + ".mode", Msg: fmt.Sprintf("unknown loop mode %q", def.Mode)}
+	}
+	if def.Yoyo {
+		switch {
+		case def.Mode == "":
+			mode = gween.LoopYoyo
+		case mode != gween.LoopYoyo && mode != gween.LoopPingPong:
+			return 0, &Error{Path: path + ".yoyo", Msg: fmt.Sprintf("yoyo cannot be combined with mode %q", def.Mode)}
+		}
+	}
+	return mode, nil
+}
+
+// describeLoop sets how the definition loops, leaving out the defaults.
+func (def *Definition) describeLoop(loop int, mode gween.LoopMode) {
+	if loop != 1 {
+		def.Loop = loop
+	}
+	switch mode {
+	case gween.LoopRestart:
+	case gween.LoopYoyo:
+		def.Yoyo = true
+	default:
+		def.Mode = mode.String()
+	}
+}
+
+// DescribeAll returns the definitions of a set of named animations.
+func DescribeAll(animations map[string]gween.Animation) (map[string]*Definition, error) {
+	defs := make(map[string]*Definition, len(animations))
+	for name, animation := range animations {
+		def, err := Describe(animation)
+		if err, ok := err.(*Error); ok {
+			return nil, &Error{Path: name + "." + err.Path, Msg: err.Msg}
+		}
+		if err != nil {
+			return nil, &Error{Path: name, Msg: err.Error()}
+		}
+		defs[name] = def
+	}
+	return defs, nil
+}
+
+// Describe returns the definition of a Tween or Sequence. It fails for Tweens that
+// use custom easing functions, which cannot be referred to by name. Failures in a
+// step of a Sequence are reported as an *Error whose path points at the step.
+func Describe(animation gween.Animation) (*Definition, error) {
+	switch animation := animation.(type) {
+	case *gween.Tween:
+		def, err := describeTween(animation)
+		if err != nil {
+			return nil, err
+		}
+		// Yoyo and ping-pong Tweens turn around on their way back
+		state := animation.Snapshot()
+		def.Reverse = state.Reverse != state.Returning
+		return def, nil
+	case *gween.Sequence:
+		def := &Definition{
+			ID:      animation.ID(),
+			Tags:    animation.Tags(),
+			Reverse: animation.Reverse() && !animation.Yoyo(),
+		}
+		def.describeLoop(animation.Loop(), animation.LoopMode())
+		def.Sequence = make([]*Definition, len(animation.Tweens))
+		for i, tween := range animation.Tweens {
+			step, err := describeTween(tween)
+			if err != nil {
+				return nil, &Error{Path: fmt.Sprintf("sequence[%d]", i), Msg: err.Error()}
+			}
+			def.Sequence[i] = step
+		}
+		return def, nil
+	}
+	return nil, fmt.Errorf("cannot describe %T", animation)
+}
+
+func describeTween(tween *gween.Tween) (*Definition, error) {
+	name, ok := ease.Name(tween.Easing())
+	if !ok {
+		return nil, fmt.Errorf("custom easing functions cannot be described")
+	}
+	def := &Definition{ID: tween.ID(), Tags: tween.Tags()}
+	switch {
+	case tween.Kind() == gween.KindCallback:
+		return nil, fmt.Errorf("callbacks cannot be described")
+	case tween.Kind() == gween.KindDelay:
+		def.Delay = float32p(tween.Duration())
+		return def, nil
+	case tween.Kind() == gween.KindBy:
+		def.By = float32p(tween.End() - tween.Begin())
+	case tween.Kind() == gween.KindFrom:
+		def.From = float32p(tween.Begin())
+	default:
+		def.Begin, def.End = float32p(tween.Begin()), float32p(tween.End())
+	}
+	def.Duration = tween.Duration()
+	if name != "Linear" {
+		def.Easing = name
+	}
+	if back := tween.BackEasing(); back != nil {
+		if def.BackEasing, ok = ease.Name(back); !ok {
+			return nil, fmt.Errorf("custom easing functions cannot be described")
+		}
+	}
+	def.describeLoop(tween.Loop(), tween.LoopMode())
+	return def, nil
+}
+
+func sortedNames(defs map[string]*Definition) []string {
+	names := make([]string, 0, len(defs))
+	for name := range defs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func float32p(value float32) *float32 {
+	return &value
+}
@@ -0,0 +1,249 @@
+package anim
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tanema/gween"
+	"github.com/tanema/gween/ease"
+)
+
+const definitionJSON = `{
+  "fade": {"id": "logo", "begin": 1, "end": 0, "duration": 2, "easing": "OutQuad"},
+  "pulse": {"begin": 1, "end": 2, "duration": 0.5, "easing": "OutQuad", "backEasing": "InQuad", "mode": "pingpong", "loop": -1},
+  "counter": {"mode": "incremental", "loop": 3, "sequence": [{"by": 1, "duration": 1}]},
+  "bounce": {
+    "tags": ["ui"],
+    "loop": 2,
+    "yoyo": true,
+    "sequence": [
+      {"begin": 0, "end": 10, "duration": 1},
+      {"delay": 0.5},
+      {"by": -4, "duration": 1, "easing": "InQuad"},
+      {"from": 2, "duration": 1}
+    ]
+  }
+}`
+
+const definitionYAML = `
+fade:
+  id: logo
+  begin: 1
+  end: 0
+  duration: 2
+  easing: OutQuad
+pulse: {begin: 1, end: 2, duration: 0.5, easing: OutQuad, backEasing: InQuad, mode: pingpong, loop: -1}
+counter:
+  mode: incremental
+  loop: 3
+  sequence:
+    - {by: 1, duration: 1}
+bounce:
+  tags: [ui]
+  loop: 2
+  yoyo: true
+  sequence:
+    - {begin: 0, end: 10, duration: 1}
+    - delay: 0.5
+    - {by: -4, duration: 1, easing: InQuad}
+    - {from: 2, duration: 1}
+`
+
+func TestLoad(t *testing.T) {
+	fromJSON, err := LoadJSON(strings.NewReader(definitionJSON))
+	require.NoError(t, err)
+	fromYAML, err := LoadYAML(strings.NewReader(definitionYAML))
+	require.NoError(t, err)
+
+	for _, animations := range []map[string]gween.Animation{fromJSON, fromYAML} {
+		fade := animations["fade"].(*gween.Tween)
+		assert.Equal(t, "logo", fade.ID())
+		current, _ := fade.Update(1)
+		assert.Equal(t, ease.OutQuad(1, 1, -1, 2), current)
+
+		pulse := animations["pulse"].(*gween.Tween)
+		assert.Equal(t, gween.LoopPingPong, pulse.LoopMode())
+		assert.Equal(t, -1, pulse.Loop())
+		current, _ = pulse.Update(0.75)
+		assert.Equal(t, ease.InQuad(0.25, 2, -1, 0.5), current)
+
+		counter := animations["counter"].(*gween.Sequence)
+		assert.Equal(t, gween.LoopIncremental, counter.LoopMode())
+		current, _, _ = counter.Update(2.5)
+		assert.Equal(t, float32(2.5), current)
+
+		bounce := animations["bounce"].(*gween.Sequence)
+		assert.True(t, bounce.HasTag("ui"))
+		assert.True(t, bounce.Yoyo())
+		assert.Equal(t, 2, bounce.Loop())
+		assert.Equal(t, 4, len(bounce.Tweens))
+		assert.Equal(t, gween.KindDelay, bounce.Tweens[1].Kind())
+
+		// Holds at the end of the first tween during the delay
+		current, _, _ = bounce.Update(1.25)
+		assert.Equal(t, float32(10), current)
+		current, _, _ = bounce.Update(1)
+		assert.Equal(t, ease.InQuad(0.75, 10, -4, 1), current)
+		// Animates from 2 back to where the previous tween ended
+		current, _, _ = bounce.Update(0.75)
+		assert.Equal(t, float32(4), current)
+	}
+}
+
+func TestLoad_Reverse(t *testing.T) {
+	animations, err := LoadJSON(strings.NewReader(`{"back": {"reverse": true, "loop": 2, "sequence": [
+		{"begin": 0, "end": 1, "duration": 1},
+		{"begin": 1, "end": 3, "duration": 1}
+	]}}`))
+	require.NoError(t, err)
+	back := animations["back"].(*gween.Sequence)
+	assert.True(t, back.Reverse())
+	assert.Equal(t, float32(3), back.Value())
+	current, _, isFinished := back.Update(0.5)
+	assert.Equal(t, float32(2), current)
+	assert.False(t, isFinished)
+	current, _, isFinished = back.Update(1)
+	assert.Equal(t, float32(0.5), current)
+	assert.False(t, isFinished)
+	current, _, isFinished = back.Update(1)
+	assert.Equal(t, float32(2), current)
+	assert.False(t, isFinished)
+	current, _, isFinished = back.Update(1.5)
+	assert.Equal(t, float32(0), current)
+	assert.True(t, isFinished)
+}
+
+func TestLoad_ReverseTween(t *testing.T) {
+	animations, err := LoadJSON(strings.NewReader(`{"out": {"begin": 0, "end": 10, "duration": 1, "reverse": true}}`))
+	require.NoError(t, err)
+	out := animations["out"].(*gween.Tween)
+	assert.Equal(t, float32(10), out.Value())
+	current, _ := out.Update(0.25)
+	assert.Equal(t, float32(7.5), current)
+
+	def, err := Describe(out)
+	require.NoError(t, err)
+	assert.True(t, def.Reverse)
+}
+
+func TestSave(t *testing.T) {
+	animations, err := LoadJSON(strings.NewReader(definitionJSON))
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, SaveJSON(&buf, animations))
+	reloaded, err := LoadJSON(&buf)
+	require.NoError(t, err)
+	assertSameDefinitions(t, animations, reloaded)
+
+	buf.Reset()
+	require.NoError(t, SaveYAML(&buf, animations))
+	reloaded, err = LoadYAML(&buf)
+	require.NoError(t, err)
+	assertSameDefinitions(t, animations, reloaded)
+}
+
+func TestSave_Delay(t *testing.T) {
+	animations := map[string]gween.Animation{
+		"wait": gween.NewDelay(0.5),
+		"hold": gween.NewBy(0, 0.5, ease.Linear),
+	}
+	var buf bytes.Buffer
+	require.NoError(t, SaveJSON(&buf, animations))
+	reloaded, err := LoadJSON(&buf)
+	require.NoError(t, err)
+	assertSameDefinitions(t, animations, reloaded)
+	wait := reloaded["wait"].(*gween.Tween)
+	assert.Equal(t, gween.KindDelay, wait.Kind())
+	_, isFinished := wait.Update(0.5)
+	assert.True(t, isFinished)
+	assert.Equal(t, gween.KindBy, reloaded["hold"].(*gween.Tween).Kind())
+
+	buf.Reset()
+	require.NoError(t, SaveYAML(&buf, animations))
+	reloaded, err = LoadYAML(&buf)
+	require.NoError(t, err)
+	assertSameDefinitions(t, animations, reloaded)
+	assert.Equal(t, gween.KindDelay, reloaded["wait"].(*gween.Tween).Kind())
+	assert.Equal(t, gween.KindBy, reloaded["hold"].(*gween.Tween).Kind())
+}
+
+func TestSave_CustomEasing(t *testing.T) {
+	custom := gween.New(0, 1, 1, func(t, b, c, d float32) float32 { return b })
+	err := SaveJSON(&bytes.Buffer{}, map[string]gween.Animation{"custom": custom})
+	assert.EqualError(t, err, "custom: custom easing functions cannot be described")
+}
+
+func TestSave_Callback(t *testing.T) {
+	seq := gween.NewSequence(gween.New(0, 1, 1, ease.Linear), gween.NewCallback(func() {}))
+	err := SaveJSON(&bytes.Buffer{}, map[string]gween.Animation{"cue": seq})
+	assert.EqualError(t, err, "cue.sequence[1]: callbacks cannot be described")
+	_, err = Describe(seq)
+	assert.Equal(t, &Error{Path: "sequence[1]", Msg: "callbacks cannot be described"}, err)
+}
+
+func TestBuild_Errors(t *testing.T) {
+	tests := map[string]string{
+		`{"a": {"begin": 0, "end": 1, "duration": 1, "easing": "Wobble"}}`:           `a.easing: unknown easing "Wobble"`,
+		`{"a": {"begin": 0, "end": 1, "duration": -1}}`:                              `a.duration: invalid duration -1`,
+		`{"a": {"begin": 0, "duration": 1}}`:                                         `a.begin: begin needs an end`,
+		`{"a": {"duration": 1}}`:                                                     `a: a tween needs begin and end, by or from`,
+		`{"a": {"by": 1, "from": 1, "duration": 1}}`:                                 `a.by: by cannot be combined with begin, end or from`,
+		`{"a": {"delay": 1, "reverse": true}}`:                                       `a: a delay cannot also describe a tween`,
+		`{"a": {"sequence": []}}`:                                                    `a.sequence: a sequence needs at least one step`,
+		`{"a": {"loop": -2, "sequence": [{"by": 1, "duration": 1}]}}`:                `a.loop: invalid loop count -2`,
+		`{"a": {"sequence": [{"by": 1}, {"sequence": [{"by": 1}]}]}}`:                `a.sequence[1]: sequences cannot be nested`,
+		`{"a": {"sequence": [{"by": 1}, {"delay": 1, "easing": "InQuad"}]}}`:         `a.sequence[1]: a delay cannot also describe a tween`,
+		`{"a": {"sequence": [{"by": 1}, {"delay": -1}]}}`:                            `a.sequence[1].delay: invalid delay -1`,
+		`{"a": {"sequence": [{"begin": 0, "end": 1}, {"by": 1, "easing": "Nope"}]}}`: `a.sequence[1].easing: unknown easing "Nope"`,
+		`{"a": {"duration": 1, "sequence": [{"by": 1}]}}`:                            `a: a sequence cannot also describe a tween`,
+		`{"a": {"by": 1, "duration": 1, "mode": "bounce"}}`:                          `a.mode: unknown loop mode "bounce"`,
+		`{"a": {"by": 1, "duration": 1, "backEasing": "Nope"}}`:                      `a.backEasing: unknown easing "Nope"`,
+		`{"a": {"yoyo": true, "mode": "shuffle", "sequence": [{"by": 1}]}}`:          `a.yoyo: yoyo cannot be combined with mode "shuffle"`,
+		`{"a": {"sequence": [{"by": 1}, {"delay": 1, "loop": 2}]}}`:                  `a.sequence[1]: a delay cannot also describe a tween`,
+		`{"a": {"reverse": true, "yoyo": true, "sequence": [{"by": 1}]}}`:            `a.reverse: a yoyo sequence always starts forward`,
+		`{"a": {"sequence": [{"by": 1, "reverse": true}]}}`:                          `a.sequence[0].reverse: steps play in the direction of their sequence`,
+		`{"a": null}`:                 `a: missing definition`,
+		`{"a": {"sequence": [null]}}`: `a.sequence[0]: missing definition`,
+		`{"a": {"begin": 0, "end": 1}, "b": {"sequence": [{"begin": 0, "end": 1, "to": 2}]}}`: `b.sequence[0]: unknown field "to"`,
+		`{"a": {"sequence": [{"by": 1}, {"by": 1, "duration": "long"}]}}`:                     `a.sequence[1].duration: cannot use string as float32`,
+	}
+	for input, expected := range tests {
+		_, err := LoadJSON(strings.NewReader(input))
+		assert.EqualError(t, err, expected, input)
+	}
+}
+
+func TestDefinition_BuildError(t *testing.T) {
+	negative := float32(-1)
+	for _, def := range []*Definition{{Delay: &negative}, {Duration: 1}} {
+		animation, err := def.Build("x")
+		assert.Error(t, err)
+		// Not just a nil *gween.Tween wrapped in an Animation
+		assert.True(t, animation == nil, "%T", animation)
+	}
+}
+
+func TestLoadYAML_Errors(t *testing.T) {
+	tests := map[string]string{
+		"a:\n  sequence:\n    - {by: 1}\n    - {by: 1, to: 2}\n": `a.sequence[1]: unknown field "to"`,
+		"a: {begin: 0, end: 1, duration: long}\n":                `a.duration: cannot use string as float32`,
+		"a: {begin: 0, end: 1, easing: Nope}\n":                  `a.easing: unknown easing "Nope"`,
+	}
+	for input, expected := range tests {
+		_, err := LoadYAML(strings.NewReader(input))
+		assert.EqualError(t, err, expected, input)
+	}
+}
+
+func assertSameDefinitions(t *testing.T, expected, actual map[string]gween.Animation) {
+	expectedDefs, err := DescribeAll(expected)
+	require.NoError(t, err)
+	actualDefs, err := DescribeAll(actual)
+	require.NoError(t, err)
+	assert.Equal(t, expectedDefs, actualDefs)
+}
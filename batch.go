@@ -0,0 +1,123 @@
+package gween
+
+import "github.com/tanema/gween/ease"
+
+// Batch animates many values that share a single easing function, such as the
+// particles of an effect. Rather than a *Tween per value, the state of every
+// element is kept in parallel slices so that Update runs in a single tight loop
+// over contiguous memory without allocating.
+type Batch struct {
+	easing   ease.TweenFunc
+	begin    []float32
+	end      []float32
+	change   []float32
+	duration []float32
+	time     []float32
+	values   []float32
+}
+
+// NewBatch returns a new Batch easing every element with easing, with room for
+// capacity elements before growing.
+func NewBatch(easing ease.TweenFunc, capacity int) *Batch {
+	return &Batch{
+		easing:   easing,
+		begin:    make([]float32, 0, capacity),
+		end:      make([]float32, 0, capacity),
+		change:   make([]float32, 0, capacity),
+		duration: make([]float32, 0, capacity),
+		time:     make([]float32, 0, capacity),
+		values:   make([]float32, 0, capacity),
+	}
+}
+
+// Add adds an element animating from begin to end over duration and returns its
+// index. Like Tweens, negative and NaN durations are treated as 0, and instant
+// elements are always finished at end.
+func (batch *Batch) Add(begin, end, duration float32) int {
+	duration = validDuration(duration)
+	value := begin
+	if duration == 0 {
+		value = end
+	}
+	batch.begin = append(batch.begin, begin)
+	batch.end = append(batch.end, end)
+	batch.change = append(batch.change, end-begin)
+	batch.duration = append(batch.duration, duration)
+	batch.time = append(batch.time, 0)
+	batch.values = append(batch.values, value)
+	return len(batch.values) - 1
+}
+
+// Remove removes the element at index by moving the last element into its place,
+// so the index of the last element changes to index.
+func (batch *Batch) Remove(index int) {
+	last := len(batch.values) - 1
+	if index < 0 || index > last {
+		return
+	}
+	batch.begin[index] = batch.begin[last]
+	batch.end[index] = batch.end[last]
+	batch.change[index] = batch.change[last]
+	batch.duration[index] = batch.duration[last]
+	batch.time[index] = batch.time[last]
+	batch.values[index] = batch.values[last]
+	batch.begin = batch.begin[:last]
+	batch.end = batch.end[:last]
+	batch.change = batch.change[:last]
+	batch.duration = batch.duration[:last]
+	batch.time = batch.time[:last]
+	batch.values = batch.values[:last]
+}
+
+// Clear removes every element while keeping the allocated memory for reuse.
+func (batch *Batch) Clear() {
+	batch.begin = batch.begin[:0]
+	batch.end = batch.end[:0]
+	batch.change = batch.change[:0]
+	batch.duration = batch.duration[:0]
+	batch.time = batch.time[:0]
+	batch.values = batch.values[:0]
+}
+
+// Len returns the number of elements in the Batch.
+func (batch *Batch) Len() int {
+	return len(batch.values)
+}
+
+// Update advances every element by dt and eases its value. It returns the number
+// of elements that are finished.
+func (batch *Batch) Update(dt float32) (finished int) {
+	begin, end, change, duration, values := batch.begin, batch.end, batch.change, batch.duration, batch.values
+	for i := range batch.time {
+		time := batch.time[i] + dt
+		switch {
+		case time >= duration[i]:
+			time = duration[i]
+			values[i] = end[i]
+			finished++
+		case time <= 0:
+			time = 0
+			values[i] = begin[i]
+		default:
+			values[i] = batch.easing(time, begin[i], change[i], duration[i])
+		}
+		batch.time[i] = time
+	}
+	return finished
+}
+
+// Value returns the current value of the element at index.
+func (batch *Batch) Value(index int) float32 {
+	return batch.values[index]
+}
+
+// Values returns the current value of every element, indexed like the elements.
+// The slice is reused by the next Update and must not be modified.
+func (batch *Batch) Values() []float32 {
+	return batch.values
+}
+
+// Finished returns whether the element at index has reached its end.
+func (batch *Batch) Finished(index int) bool {
+	return batch.time[index] >= batch.duration[index]
+}
@@ -0,0 +1,105 @@
+package gween
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tanema/gween/ease"
+)
+
+const benchmarkElements = 100000
+
+func TestBatch(t *testing.T) {
+	batch := NewBatch(ease.InQuad, 4)
+	assert.Equal(t, 0, batch.Add(0, 10, 10))
+	assert.Equal(t, 1, batch.Add(5, 0, 1))
+	assert.Equal(t, 2, batch.Add(1, 2, 0))
+	assert.Equal(t, 3, batch.Len())
+
+	assert.Equal(t, 2, batch.Update(5))
+	assert.Equal(t, []float32{ease.InQuad(5, 0, 10, 10), 0, 2}, batch.Values())
+	assert.False(t, batch.Finished(0))
+	assert.True(t, batch.Finished(1))
+
+	batch.Remove(0)
+	assert.Equal(t, 2, batch.Len())
+	assert.Equal(t, float32(2), batch.Value(0))
+	batch.Remove(5)
+	assert.Equal(t, 2, batch.Len())
+
+	assert.Equal(t, 0, batch.Update(-1))
+	assert.Equal(t, []float32{1, ease.InQuad(0, 5, -5, 1)}, batch.Values())
+
+	batch.Clear()
+	assert.Equal(t, 0, batch.Len())
+}
+
+func TestBatch_InvalidDuration(t *testing.T) {
+	batch := NewBatch(ease.Linear, 2)
+	batch.Add(0, 10, -1)
+	batch.Add(0, 10, float32(math.NaN()))
+	assert.Equal(t, []float32{10, 10}, batch.Values())
+	assert.True(t, batch.Finished(0))
+	assert.True(t, batch.Finished(1))
+	assert.Equal(t, 2, batch.Update(0))
+	assert.Equal(t, []float32{10, 10}, batch.Values())
+}
+
+func TestBatch_MatchesTweens(t *testing.T) {
+	batch := NewBatch(ease.OutBounce, 0)
+	var tweens []*Tween
+	for i := 0; i < 50; i++ {
+		begin, end, duration := float32(i), float32(100-i), float32(i%7+1)
+		batch.Add(begin, end, duration)
+		tweens = append(tweens, New(begin, end, duration, ease.OutBounce))
+	}
+	for step := 0; step < 20; step++ {
+		batch.Update(0.37)
+		for i, tween := range tweens {
+			value, isFinished := tween.Update(0.37)
+			assert.Equal(t, value, batch.Value(i))
+			assert.Equal(t, isFinished, batch.Finished(i))
+		}
+	}
+}
+
+func TestBatch_UpdateAllocs(t *testing.T) {
+	batch := newBenchmarkBatch()
+	allocs := testing.AllocsPerRun(10, func() {
+		batch.Update(0.001)
+	})
+	assert.Equal(t, float64(0), allocs)
+}
+
+func newBenchmarkBatch() *Batch {
+	batch := NewBatch(ease.InOutCubic, benchmarkElements)
+	for i := 0; i < benchmarkElements; i++ {
+		batch.Add(float32(i), float32(-i), float32(i%100+1))
+	}
+	return batch
+}
+
+func BenchmarkBatch_Update(b *testing.B) {
+	batch := newBenchmarkBatch()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		batch.Update(0.001)
+	}
+}
+
+func BenchmarkTweens_Update(b *testing.B) {
+	tweens := make([]*Tween, benchmarkElements)
+	for i := range tweens {
+		tweens[i] = New(float32(i), float32(-i), float32(i%100+1), ease.InOutCubic)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, tween := range tweens {
+			tween.Update(0.001)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+// Command gween-plot renders every built in easing function of the ease package
+// to a gallery directory, as one image per easing function and an index.html
+// page showing them all.
+//
+// Usage:
+//
+//	gween-plot [-out dir] [-format svg,png] [-width 480] [-height 320]
+package main
+
+import (
+	"flag"
+	"fmt"
+	"html"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/tanema/gween/ease"
+	"github.com/tanema/gween/plot"
+)
+
+func main() {
+	out := flag.String("out", "gallery", "directory to write the gallery to")
+	formats := flag.String("format", "svg,png", "comma separated image formats to render, svg and/or png")
+	width := flag.Int("width", 480, "width of each image in pixels")
+	height := flag.Int("height", 320, "height of each image in pixels")
+	samples := flag.Int("samples", plot.DefaultSamples, "number of samples taken along each curve")
+	flag.Parse()
+
+	if err := render(*out, strings.Split(*formats, ","), *width, *height, *samples); err != nil {
+		fmt.Fprintln(os.Stderr, "gween-plot:", err)
+		os.Exit(1)
+	}
+}
+
+// render writes an image of every built in easing function in each format, and
+// an index.html page of them, to dir.
+func render(dir string, formats []string, width, height, samples int) error {
+	for _, format := range formats {
+		if format != "svg" && format != "png" {
+			return fmt.Errorf("unknown format %q", format)
+		}
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	var index strings.Builder
+	index.WriteString("<!DOCTYPE html>\n<html>\n<head><title>gween easing functions</title></head>\n<body>\n")
+	for _, name := range ease.Names() {
+		fn, _ := ease.ByName(name)
+		p := plot.New(name, plot.Ease(name, fn, samples))
+		p.Width, p.Height = width, height
+		for _, format := range formats {
+			if err := write(filepath.Join(dir, name+"."+format), p, format); err != nil {
+				return err
+			}
+		}
+		fmt.Fprintf(&index, "<figure style=\"display:inline-block\"><img src=\"%s.%s\" alt=\"%s\"><figcaption>%s</figcaption></figure>\n",
+			html.EscapeString(name), formats[0], html.EscapeString(name), html.EscapeString(name))
+	}
+	index.WriteString("</body>\n</html>\n")
+	return ioutil.WriteFile(filepath.Join(dir, "index.html"), []byte(index.String()), 0644)
+}
+
+// write renders the plot to the file at path in format.
+func write(path string, p *plot.Plot, format string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if format == "png" {
+		err = p.PNG(file)
+	} else {
+		err = p.SVG(file)
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tanema/gween/ease"
+)
+
+func TestRender(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gween-plot")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	assert.Nil(t, render(dir, []string{"svg", "png"}, 200, 100, 50))
+	for _, name := range ease.Names() {
+		assert.FileExists(t, filepath.Join(dir, name+".svg"))
+		assert.FileExists(t, filepath.Join(dir, name+".png"))
+	}
+	index, err := ioutil.ReadFile(filepath.Join(dir, "index.html"))
+	assert.Nil(t, err)
+	assert.Contains(t, string(index), `src="OutBounce.svg"`)
+
+	assert.NotNil(t, render(dir, []string{"gif"}, 200, 100, 50))
+}
@@ -0,0 +1,188 @@
+// Command gween-preview prints a plot of an easing function, or of every
+// animation in an animation definition file, to the terminal along with a table
+// of sampled values. Animations can also be played back live.
+//
+// Usage:
+//
+//	gween-preview [flags] <easing name | definition file>
+//
+// Definition files are read as YAML when they end in .yaml or .yml, and as JSON
+// otherwise. See the anim package for their format.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tanema/gween"
+	"github.com/tanema/gween/anim"
+	"github.com/tanema/gween/ease"
+	"github.com/tanema/gween/plot"
+)
+
+// options configures the output of gween-preview.
+type options struct {
+	columns int
+	rows    int
+	samples int
+	ascii   bool
+	play    bool
+	name    string
+	fps     int
+}
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "gween-preview:", err)
+		os.Exit(2)
+	}
+}
+
+// run parses the command line arguments and writes the preview to out.
+func run(args []string, out io.Writer) error {
+	var opts options
+	flags := flag.NewFlagSet("gween-preview", flag.ContinueOnError)
+	flags.SetOutput(out)
+	flags.IntVar(&opts.columns, "width", 60, "width of the plot in characters")
+	flags.IntVar(&opts.rows, "height", 15, "height of the plot in characters")
+	flags.IntVar(&opts.samples, "samples", 10, "number of intervals to sample the animation over, the table of sampled values has one more row")
+	flags.BoolVar(&opts.ascii, "ascii", false, "plot with plain ASCII rather than Unicode braille")
+	flags.BoolVar(&opts.play, "play", false, "play each animation back live after plotting it")
+	flags.StringVar(&opts.name, "name", "", "only preview the animation with this name from a definition file")
+	flags.IntVar(&opts.fps, "fps", 30, "frames per second of live playback")
+	flags.Usage = func() {
+		fmt.Fprintln(out, "usage: gween-preview [flags] <easing name | definition file>")
+		flags.PrintDefaults()
+		fmt.Fprintln(out, "easing functions:", strings.Join(ease.Names(), ", "))
+	}
+	if err := flags.Parse(args); err == flag.ErrHelp {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return errors.New("expected a single easing name or definition file")
+	}
+
+	animations, err := load(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	names := make([]string, 0, len(animations))
+	for name := range animations {
+		if opts.name == "" || name == opts.name {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return fmt.Errorf("no animation named %q", opts.name)
+	}
+	sort.Strings(names)
+
+	for i, name := range names {
+		if i > 0 {
+			fmt.Fprintln(out)
+		}
+		if err := preview(out, name, animations[name], opts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// load returns the animations to preview from an easing name or definition file.
+// An easing function is previewed as a Tween from 0 to 1 over 1 second.
+func load(arg string) (map[string]gween.Animation, error) {
+	if fn, ok := ease.ByName(arg); ok {
+		return map[string]gween.Animation{arg: gween.New(0, 1, 1, fn)}, nil
+	}
+	file, err := os.Open(arg)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("%q is neither an easing function nor a file", arg)
+	} else if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	switch strings.ToLower(filepath.Ext(arg)) {
+	case ".yaml", ".yml":
+		return anim.LoadYAML(file)
+	default:
+		return anim.LoadJSON(file)
+	}
+}
+
+// preview writes the plot and table of sampled values of the animation to out,
+// then plays it back when requested.
+func preview(out io.Writer, name string, animation gween.Animation, opts options) error {
+	curve := plot.Animation(name, animation, 0)
+	p := plot.New(name, curve)
+	var err error
+	if opts.ascii {
+		err = p.ASCII(out, opts.columns, opts.rows)
+	} else {
+		err = p.Braille(out, opts.columns, opts.rows)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(out)
+	table(out, plot.Animation(name, animation, opts.samples))
+	if opts.play {
+		fmt.Fprintln(out)
+		return play(out, name, animation, curve, opts)
+	}
+	return nil
+}
+
+// table writes the time and value of every point of the curve to out.
+func table(out io.Writer, curve plot.Curve) {
+	fmt.Fprintf(out, "%10s  %10s\n", "time", "value")
+	for _, point := range curve.Points {
+		fmt.Fprintf(out, "%10.4f  %10.4f\n", point.X, point.Y)
+	}
+}
+
+// play runs the animation in real time, redrawing a bar showing its value on a
+// single line until it completes.
+func play(out io.Writer, name string, animation gween.Animation, curve plot.Curve, opts options) error {
+	fps := opts.fps
+	if fps <= 0 {
+		fps = 30
+	}
+	runner := gween.NewRunner(animation, time.Second/time.Duration(fps))
+	runner.Start(context.Background())
+	fill := "█"
+	if opts.ascii {
+		fill = "#"
+	}
+	for value := range runner.Values() {
+		fmt.Fprintf(out, "\r%s |%s| %10.4f", name, bar(value, curve.Low, curve.High, opts.columns, fill), value)
+	}
+	fmt.Fprintln(out)
+	return runner.Err()
+}
+
+// bar returns a bar of width characters filled in proportion to where value lies
+// between low and high.
+func bar(value float32, low, high float64, width int, fill string) string {
+	filled := width
+	if high > low {
+		filled = int(math.Round((float64(value) - low) / (high - low) * float64(width)))
+	}
+	if filled < 0 {
+		filled = 0
+	} else if filled > width {
+		filled = width
+	}
+	return strings.Repeat(fill, filled) + strings.Repeat(" ", width-filled)
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRun_Easing(t *testing.T) {
+	var out bytes.Buffer
+	assert.Nil(t, run([]string{"-samples", "4", "InQuad"}, &out))
+	assert.Contains(t, out.String(), "InQuad")
+	assert.Contains(t, out.String(), "    0.5000      0.2500\n")
+	assert.Contains(t, out.String(), "    1.0000      1.0000\n")
+
+	assert.NotNil(t, run([]string{"NotAnEasing"}, &out))
+	assert.NotNil(t, run([]string{}, &out))
+}
+
+func TestRun_File(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gween-preview")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "anims.yaml")
+	assert.Nil(t, ioutil.WriteFile(path, []byte(`
+fade:
+  begin: 0
+  end: 1
+  duration: 1
+  easing: Linear
+slide:
+  sequence:
+    - {begin: 0, end: 10, duration: 1, easing: Linear}
+    - {by: 10, duration: 1, easing: Linear}
+`), 0644))
+
+	var out bytes.Buffer
+	assert.Nil(t, run([]string{"-ascii", "-samples", "2", path}, &out))
+	assert.Contains(t, out.String(), "fade")
+	assert.Contains(t, out.String(), "    2.0000     20.0000\n")
+	assert.True(t, strings.Index(out.String(), "fade") < strings.Index(out.String(), "slide"))
+
+	out.Reset()
+	assert.Nil(t, run([]string{"-name", "fade", "-play", "-fps", "100", path}, &out))
+	assert.NotContains(t, out.String(), "slide")
+	assert.Contains(t, out.String(), "\rfade |")
+
+	assert.NotNil(t, run([]string{"-name", "missing", path}, &out))
+}
+
+func TestBar(t *testing.T) {
+	assert.Equal(t, "##  ", bar(5, 0, 10, 4, "#"))
+	assert.Equal(t, "####", bar(12, 0, 10, 4, "#"))
+	assert.Equal(t, "    ", bar(-1, 0, 10, 4, "#"))
+}
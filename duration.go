@@ -0,0 +1,83 @@
+package gween
+
+import (
+	"time"
+
+	"github.com/tanema/gween/ease"
+)
+
+// NewWithDuration returns a new Tween like New, taking its duration as a
+// time.Duration. Durations are converted to seconds, so a Tween created this way
+// should be updated with UpdateDuration or with dt measured in seconds.
+func NewWithDuration(begin, end float32, duration time.Duration, easing ease.TweenFunc) *Tween {
+	return New(begin, end, seconds(duration), easing)
+}
+
+// NewByWithDuration returns a new relative Tween like NewBy, taking its duration
+// as a time.Duration.
+func NewByWithDuration(delta float32, duration time.Duration, easing ease.TweenFunc) *Tween {
+	return NewBy(delta, seconds(duration), easing)
+}
+
+// NewFromWithDuration returns a new Tween like NewFrom, taking its duration as a
+// time.Duration.
+func NewFromWithDuration(from float32, duration time.Duration, easing ease.TweenFunc) *Tween {
+	return NewFrom(from, seconds(duration), easing)
+}
+
+// NewDelayWithDuration returns a new delay like NewDelay, taking its duration as
+// a time.Duration.
+func NewDelayWithDuration(duration time.Duration) *Tween {
+	return NewDelay(seconds(duration))
+}
+
+// UpdateDuration is Update taking dt as a time.Duration, measured in seconds.
+func (tween *Tween) UpdateDuration(dt time.Duration) (current float32, isFinished bool) {
+	return tween.Update(seconds(dt))
+}
+
+// UpdateDuration is Update taking dt as a time.Duration, measured in seconds.
+func (seq *Sequence) UpdateDuration(dt time.Duration) (value float32, tweenComplete, sequenceComplete bool) {
+	return seq.Update(seconds(dt))
+}
+
+// UpdateDuration is Update taking dt as a time.Duration, measured in seconds.
+func (group *Group) UpdateDuration(dt time.Duration) (isFinished bool) {
+	return group.Update(seconds(dt))
+}
+
+// Clock measures the time elapsed between successive calls to Tick, to be passed
+// to UpdateDuration. It reads the time from time.Now unless another time source
+// is provided, which allows the passage of time to be controlled in tests.
+type Clock struct {
+	now  func() time.Time
+	last time.Time
+}
+
+// NewClock returns a new Clock reading the time from time.Now.
+func NewClock() *Clock {
+	return NewClockWith(time.Now)
+}
+
+// NewClockWith returns a new Clock reading the time from now.
+func NewClockWith(now func() time.Time) *Clock {
+	return &Clock{now: now, last: now()}
+}
+
+// Tick returns the time elapsed since the previous Tick, or since the Clock was
+// created or Reset.
+func (clock *Clock) Tick() time.Duration {
+	now := clock.now()
+	dt := now.Sub(clock.last)
+	clock.last = now
+	return dt
+}
+
+// Reset restarts the measurement of elapsed time from now.
+func (clock *Clock) Reset() {
+	clock.last = clock.now()
+}
+
+func seconds(d time.Duration) float32 {
+	return float32(d.Seconds())
+}
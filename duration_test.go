@@ -0,0 +1,73 @@
+package gween
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tanema/gween/ease"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (clock *fakeClock) Now() time.Time {
+	return clock.now
+}
+
+func (clock *fakeClock) Advance(d time.Duration) {
+	clock.now = clock.now.Add(d)
+}
+
+func TestNewWithDuration(t *testing.T) {
+	tween := NewWithDuration(0, 10, 2*time.Second, ease.Linear)
+	assert.Equal(t, float32(2), tween.duration)
+	current, isFinished := tween.UpdateDuration(500 * time.Millisecond)
+	assert.Equal(t, float32(2.5), current)
+	assert.False(t, isFinished)
+
+	tween = NewByWithDuration(4, 250*time.Millisecond, ease.Linear)
+	tween.Resolve(1)
+	current, isFinished = tween.UpdateDuration(250 * time.Millisecond)
+	assert.Equal(t, float32(5), current)
+	assert.True(t, isFinished)
+
+	tween = NewFromWithDuration(4, time.Second, ease.Linear)
+	assert.Equal(t, float32(1), tween.duration)
+
+	tween = NewDelayWithDuration(1500 * time.Millisecond)
+	assert.Equal(t, float32(1.5), tween.duration)
+}
+
+func TestSequence_UpdateDuration(t *testing.T) {
+	seq := NewSequence(
+		NewWithDuration(0, 1, time.Second, ease.Linear),
+		NewWithDuration(1, 2, time.Second, ease.Linear),
+	)
+	current, tweenComplete, seqComplete := seq.UpdateDuration(1500 * time.Millisecond)
+	assert.Equal(t, float32(1.5), current)
+	assert.True(t, tweenComplete)
+	assert.False(t, seqComplete)
+	assert.True(t, NewGroup(seq).UpdateDuration(time.Second))
+}
+
+func TestClock(t *testing.T) {
+	fake := &fakeClock{now: time.Unix(100, 0)}
+	clock := NewClockWith(fake.Now)
+	fake.Advance(16 * time.Millisecond)
+	assert.Equal(t, 16*time.Millisecond, clock.Tick())
+	assert.Equal(t, time.Duration(0), clock.Tick())
+	fake.Advance(time.Second)
+	clock.Reset()
+	fake.Advance(time.Millisecond)
+	assert.Equal(t, time.Millisecond, clock.Tick())
+
+	tween := NewWithDuration(0, 10, time.Second, ease.Linear)
+	fake.Advance(100 * time.Millisecond)
+	current, _ := tween.UpdateDuration(clock.Tick())
+	assert.Equal(t, float32(1), current)
+
+	assert.True(t, NewClock().Tick() >= 0)
+}
@@ -0,0 +1,226 @@
+package ease
+
+import (
+	"math"
+	"reflect"
+)
+
+// ln2x10 is the rate of the exponential 2^(10t) used by the Expo and Elastic
+// families.
+var ln2x10 = float32(10 * math.Ln2)
+
+// elasticW is the angular frequency of the Elastic family, whose period is 0.3 of
+// the duration.
+var elasticW = 2 * pi / 0.3
+
+// derivatives holds the first and second derivative of an easing function with
+// respect to t, using the same arguments as the easing function itself.
+type derivatives struct {
+	velocity     TweenFunc
+	acceleration TweenFunc
+}
+
+// builtinDerivatives maps each built in easing function to its analytic
+// derivatives.
+var builtinDerivatives = map[uintptr]derivatives{}
+
+func init() {
+	quad, cubic, quart, quint := power(2), power(3), power(4), power(5)
+	back := backShape(backS)
+	inOutBack := backShape(backS * 1.525)
+	expoOut := expoOutShape(1.001)
+	bounce := bounceShape()
+
+	families := []struct {
+		in, out, inOut, outIn TweenFunc
+		inShape, outShape     shape
+		inOutShape            [2]shape
+	}{
+		{InQuad, OutQuad, InOutQuad, OutInQuad, quad, quad.mirror(), [2]shape{quad, quad.mirror()}},
+		{InCubic, OutCubic, InOutCubic, OutInCubic, cubic, cubic.mirror(), [2]shape{cubic, cubic.mirror()}},
+		{InQuart, OutQuart, InOutQuart, OutInQuart, quart, quart.mirror(), [2]shape{quart, quart.mirror()}},
+		{InQuint, OutQuint, InOutQuint, OutInQuint, quint, quint.mirror(), [2]shape{quint, quint.mirror()}},
+		{InSine, OutSine, InOutSine, OutInSine, sineShape, sineShape.mirror(), [2]shape{sineShape, sineShape.mirror()}},
+		{InCirc, OutCirc, InOutCirc, OutInCirc, circShape, circShape.mirror(), [2]shape{circShape, circShape.mirror()}},
+		{InBack, OutBack, InOutBack, OutInBack, back, back.mirror(), [2]shape{inOutBack, inOutBack.mirror()}},
+		{InExpo, OutExpo, InOutExpo, OutInExpo, expoInShape, expoOut, [2]shape{expoInShape, expoOutShape(1.0005)}},
+		{InElastic, OutElastic, InOutElastic, OutInElastic, elasticShape, elasticShape.mirror(), [2]shape{elasticShape, elasticShape.mirror()}},
+		{InBounce, OutBounce, InOutBounce, OutInBounce, bounce.mirror(), bounce, [2]shape{bounce.mirror(), bounce}},
+	}
+
+	builtinDerivatives[funcKey(Linear)] = derivatives{
+		velocity:     func(t, b, c, d float32) float32 { return c / d },
+		acceleration: func(t, b, c, d float32) float32 { return 0 },
+	}
+	for _, family := range families {
+		in, out := family.inShape.derivatives(), family.outShape.derivatives()
+		builtinDerivatives[funcKey(family.in)] = in
+		builtinDerivatives[funcKey(family.out)] = out
+		builtinDerivatives[funcKey(family.inOut)] = split(family.inOutShape[0].derivatives(), family.inOutShape[1].derivatives())
+		builtinDerivatives[funcKey(family.outIn)] = split(out, in)
+	}
+}
+
+// Velocity returns the rate of change of the easing function fn at time t, that is
+// its first derivative with respect to t. Built in easing functions are derived
+// analytically, any other function is differentiated numerically.
+func Velocity(fn TweenFunc, t, b, c, d float32) float32 {
+	if derived, ok := builtinDerivatives[funcKey(fn)]; ok {
+		return derived.velocity(t, b, c, d)
+	}
+	h := d * 1e-3
+	t0, t1 := clampRange(t-h, d), clampRange(t+h, d)
+	return (fn(t1, b, c, d) - fn(t0, b, c, d)) / (t1 - t0)
+}
+
+// Acceleration returns the rate of change of the velocity of the easing function
+// fn at time t, that is its second derivative with respect to t. Built in easing
+// functions are derived analytically, any other function is differentiated
+// numerically.
+func Acceleration(fn TweenFunc, t, b, c, d float32) float32 {
+	if derived, ok := builtinDerivatives[funcKey(fn)]; ok {
+		return derived.acceleration(t, b, c, d)
+	}
+	h := d * 1e-2
+	t = clampRange(t, d-h)
+	if t < h {
+		t = h
+	}
+	return (fn(t+h, b, c, d) - 2*fn(t, b, c, d) + fn(t-h, b, c, d)) / (h * h)
+}
+
+// shape holds the first and second derivative of a normalized easing curve f(s)
+// with s in [0, 1], eased as b + c*f(t/d).
+type shape struct {
+	d1, d2 func(s float32) float32
+}
+
+// derivatives scales the derivatives of the normalized curve back to t, b, c, d.
+func (sh shape) derivatives() derivatives {
+	return derivatives{
+		velocity: func(t, b, c, d float32) float32 {
+			return c / d * sh.d1(t/d)
+		},
+		acceleration: func(t, b, c, d float32) float32 {
+			return c / (d * d) * sh.d2(t/d)
+		},
+	}
+}
+
+// mirror returns the shape of the opposite transition 1-f(1-s), turning an In
+// curve into its Out counterpart and vice versa.
+func (sh shape) mirror() shape {
+	return shape{
+		d1: func(s float32) float32 { return sh.d1(1 - s) },
+		d2: func(s float32) float32 { return -sh.d2(1 - s) },
+	}
+}
+
+// split returns the derivatives of an easing made of first over the first half of
+// the duration and second over the second half, each covering half the change.
+func split(first, second derivatives) derivatives {
+	return derivatives{
+		velocity: func(t, b, c, d float32) float32 {
+			if t < d/2 {
+				return 2 * first.velocity(t*2, b, c/2, d)
+			}
+			return 2 * second.velocity(t*2-d, b+c/2, c/2, d)
+		},
+		acceleration: func(t, b, c, d float32) float32 {
+			if t < d/2 {
+				return 4 * first.acceleration(t*2, b, c/2, d)
+			}
+			return 4 * second.acceleration(t*2-d, b+c/2, c/2, d)
+		},
+	}
+}
+
+// power is the shape of s^n
+func power(n float32) shape {
+	return shape{
+		d1: func(s float32) float32 { return n * pow(s, n-1) },
+		d2: func(s float32) float32 { return n * (n - 1) * pow(s, n-2) },
+	}
+}
+
+// sineShape is the shape of 1-cos(s*pi/2)
+var sineShape = shape{
+	d1: func(s float32) float32 { return pi / 2 * sin(s*pi/2) },
+	d2: func(s float32) float32 { return pi * pi / 4 * cos(s*pi/2) },
+}
+
+// circShape is the shape of 1-sqrt(1-s^2)
+var circShape = shape{
+	d1: func(s float32) float32 { return s / sqrt(1-s*s) },
+	d2: func(s float32) float32 { return 1 / pow(1-s*s, 1.5) },
+}
+
+// expoInShape is the shape of 2^(10(s-1)), offset so that it starts near 0
+var expoInShape = shape{
+	d1: func(s float32) float32 { return ln2x10 * pow(2, 10*(s-1)) },
+	d2: func(s float32) float32 { return ln2x10 * ln2x10 * pow(2, 10*(s-1)) },
+}
+
+// elasticShape is the shape of -2^(10(s-1))*sin((s-1.075)*w)
+var elasticShape = shape{
+	d1: func(s float32) float32 {
+		e, phase := pow(2, 10*(s-1)), (s-1.075)*elasticW
+		return -e * (ln2x10*sin(phase) + elasticW*cos(phase))
+	},
+	d2: func(s float32) float32 {
+		e, phase := pow(2, 10*(s-1)), (s-1.075)*elasticW
+		return -e * ((ln2x10*ln2x10-elasticW*elasticW)*sin(phase) + 2*ln2x10*elasticW*cos(phase))
+	},
+}
+
+// backShape is the shape of s^2*((overshoot+1)*s - overshoot)
+func backShape(overshoot float32) shape {
+	return shape{
+		d1: func(s float32) float32 { return 3*(overshoot+1)*s*s - 2*overshoot*s },
+		d2: func(s float32) float32 { return 6*(overshoot+1)*s - 2*overshoot },
+	}
+}
+
+// expoOutShape is the shape of scale*(1-2^(-10s))
+func expoOutShape(scale float32) shape {
+	return shape{
+		d1: func(s float32) float32 { return scale * ln2x10 * pow(2, -10*s) },
+		d2: func(s float32) float32 { return -scale * ln2x10 * ln2x10 * pow(2, -10*s) },
+	}
+}
+
+// bounceShape is the shape of OutBounce, a series of parabolas of the form
+// 7.5625*(s-offset)^2 + height
+func bounceShape() shape {
+	offset := func(s float32) float32 {
+		switch {
+		case s < 1/2.75:
+			return 0
+		case s < 2/2.75:
+			return 1.5 / 2.75
+		case s < 2.5/2.75:
+			return 2.25 / 2.75
+		}
+		return 2.625 / 2.75
+	}
+	return shape{
+		d1: func(s float32) float32 { return 2 * 7.5625 * (s - offset(s)) },
+		d2: func(s float32) float32 { return 2 * 7.5625 },
+	}
+}
+
+// funcKey identifies a function so that built in easing functions can be
+// recognized.
+func funcKey(fn TweenFunc) uintptr {
+	return reflect.ValueOf(fn).Pointer()
+}
+
+func clampRange(t, d float32) float32 {
+	if t < 0 {
+		return 0
+	}
+	if t > d {
+		return d
+	}
+	return t
+}
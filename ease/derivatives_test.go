@@ -0,0 +1,54 @@
+package ease
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDerivatives(t *testing.T) {
+	const (
+		b, c, d = float32(2), float32(3), float32(4)
+		h       = float64(1e-4)
+	)
+	for name, fn := range byName {
+		t.Run(name, func(t *testing.T) {
+			value := func(t float64) float64 {
+				return float64(fn(float32(t), b, c, d))
+			}
+			// Sample between the breakpoints of the piecewise functions
+			for i := 0; i < 20; i++ {
+				tm := (float64(i) + 0.5) / 20 * float64(d)
+				velocity := (value(tm+h) - value(tm-h)) / (2 * h)
+				if got := float64(Velocity(fn, float32(tm), b, c, d)); !near(got, velocity, 0.02) {
+					t.Fatalf("velocity at %v: expected %v got %v", tm, velocity, got)
+				}
+				velocityDelta := float64(Velocity(fn, float32(tm+h), b, c, d) - Velocity(fn, float32(tm-h), b, c, d))
+				if got := float64(Acceleration(fn, float32(tm), b, c, d)); !near(got, velocityDelta/(2*h), 0.05) {
+					t.Fatalf("acceleration at %v: expected %v got %v", tm, velocityDelta/(2*h), got)
+				}
+			}
+		})
+	}
+}
+
+func TestDerivatives_Custom(t *testing.T) {
+	square := func(t, b, c, d float32) float32 {
+		return c*(t/d)*(t/d) + b
+	}
+	if got := Velocity(square, 2, 0, 4, 4); !near(float64(got), 1, 0.01) {
+		t.Fatalf("expected velocity 1, got %v", got)
+	}
+	if got := Velocity(square, 0, 0, 4, 4); !near(float64(got), 0, 0.01) {
+		t.Fatalf("expected velocity 0, got %v", got)
+	}
+	if got := Acceleration(square, 2, 0, 4, 4); !near(float64(got), 0.5, 0.01) {
+		t.Fatalf("expected acceleration 0.5, got %v", got)
+	}
+	if got := Acceleration(square, 4, 0, 4, 4); !near(float64(got), 0.5, 0.01) {
+		t.Fatalf("expected acceleration 0.5, got %v", got)
+	}
+}
+
+func near(got, expected, tolerance float64) bool {
+	return math.Abs(got-expected) <= tolerance*math.Max(1, math.Abs(expected))
+}
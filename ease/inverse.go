@@ -0,0 +1,172 @@
+package ease
+
+import (
+	"math"
+	"sort"
+)
+
+// inverseSamples is the number of intervals the duration is split into when
+// looking for crossings numerically.
+const inverseSamples = 512
+
+// inverse solves a normalized easing curve f(s) = y for s in [0, 1].
+type inverse func(y float32) (s float32, ok bool)
+
+// builtinInverses maps the built in easing functions that can be solved in closed
+// form to their inverse.
+var builtinInverses = map[uintptr]inverse{}
+
+func init() {
+	quad, cubic, quart, quint := root(2), root(3), root(4), root(5)
+	families := []struct {
+		in, out, inOut, outIn TweenFunc
+		inverse               inverse
+	}{
+		{InQuad, OutQuad, InOutQuad, OutInQuad, quad},
+		{InCubic, OutCubic, InOutCubic, OutInCubic, cubic},
+		{InQuart, OutQuart, InOutQuart, OutInQuart, quart},
+		{InQuint, OutQuint, InOutQuint, OutInQuint, quint},
+		{InSine, OutSine, InOutSine, OutInSine, sineInverse},
+		{InCirc, OutCirc, InOutCirc, OutInCirc, circInverse},
+	}
+	builtinInverses[funcKey(Linear)] = func(y float32) (float32, bool) {
+		return y, y >= 0 && y <= 1
+	}
+	for _, family := range families {
+		in, out := family.inverse, family.inverse.mirror()
+		builtinInverses[funcKey(family.in)] = in
+		builtinInverses[funcKey(family.out)] = out
+		builtinInverses[funcKey(family.inOut)] = splitInverse(in, out)
+		builtinInverses[funcKey(family.outIn)] = splitInverse(out, in)
+	}
+}
+
+// Inverse returns every time t in [0, d], in ascending order, at which the easing
+// function fn reaches value. Easing functions that overshoot, like OutBack or
+// OutElastic, can cross the same value several times, in which case every
+// crossing is returned. Built in easing functions are solved in closed form where
+// possible, any other function is solved numerically by bracketing the crossings
+// and refining them with Newton's method, falling back to bisection.
+func Inverse(fn TweenFunc, value, b, c, d float32) []float32 {
+	if d <= 0 {
+		return nil
+	}
+	if inv, ok := builtinInverses[funcKey(fn)]; ok && c != 0 {
+		s, ok := inv((value - b) / c)
+		if !ok {
+			return nil
+		}
+		return []float32{s * d}
+	}
+
+	var times []float32
+	step := d / inverseSamples
+	t0 := float32(0)
+	f0 := fn(t0, b, c, d) - value
+	if f0 == 0 {
+		times = append(times, t0)
+	}
+	for i := 1; i <= inverseSamples; i++ {
+		t1 := float32(i) * step
+		if i == inverseSamples {
+			t1 = d
+		}
+		f1 := fn(t1, b, c, d) - value
+		switch {
+		case f1 == 0:
+			times = append(times, t1)
+		case f0 != 0 && (f0 < 0) != (f1 < 0):
+			if t, ok := refine(fn, value, b, c, d, t0, t1, f0); ok {
+				times = append(times, t)
+			}
+		}
+		t0, f0 = t1, f1
+	}
+	sort.Slice(times, func(i, j int) bool { return times[i] < times[j] })
+	return times
+}
+
+// refine narrows down the crossing of value bracketed by lo and hi, where f(lo)
+// is flo. It returns false if the bracket turns out to be a discontinuity rather
+// than a crossing.
+func refine(fn TweenFunc, value, b, c, d, lo, hi, flo float32) (float32, bool) {
+	t := (lo + hi) / 2
+	for i := 0; i < 64 && hi-lo > d*1e-7; i++ {
+		ft := fn(t, b, c, d) - value
+		if ft == 0 {
+			return t, true
+		}
+		if (ft < 0) == (flo < 0) {
+			lo, flo = t, ft
+		} else {
+			hi = t
+		}
+		// Take a Newton step when it stays within the bracket, otherwise bisect
+		next := (lo + hi) / 2
+		if velocity := Velocity(fn, t, b, c, d); velocity != 0 {
+			if newton := t - ft/velocity; newton > lo && newton < hi {
+				next = newton
+			}
+		}
+		t = next
+	}
+	scale := float32(math.Max(1, math.Abs(float64(c))))
+	if abs(fn(t, b, c, d)-value) > scale*1e-3 {
+		return 0, false
+	}
+	return t, true
+}
+
+// mirror returns the inverse of the opposite transition 1-f(1-s).
+func (inv inverse) mirror() inverse {
+	return func(y float32) (float32, bool) {
+		s, ok := inv(1 - y)
+		return 1 - s, ok
+	}
+}
+
+// splitInverse returns the inverse of a curve made of first over the first half
+// and second over the second half, each covering half the change.
+func splitInverse(first, second inverse) inverse {
+	return func(y float32) (float32, bool) {
+		if y <= 0.5 {
+			s, ok := first(y * 2)
+			return s / 2, ok
+		}
+		s, ok := second(y*2 - 1)
+		return 0.5 + s/2, ok
+	}
+}
+
+// root is the inverse of s^n
+func root(n float32) inverse {
+	return func(y float32) (float32, bool) {
+		if y < 0 || y > 1 {
+			return 0, false
+		}
+		return pow(y, 1/n), true
+	}
+}
+
+// sineInverse is the inverse of 1-cos(s*pi/2)
+func sineInverse(y float32) (float32, bool) {
+	if y < 0 || y > 1 {
+		return 0, false
+	}
+	return float32(math.Acos(float64(1-y))) * 2 / pi, true
+}
+
+// circInverse is the inverse of 1-sqrt(1-s^2)
+func circInverse(y float32) (float32, bool) {
+	if y < 0 || y > 1 {
+		return 0, false
+	}
+	return sqrt(1 - (1-y)*(1-y)), true
+}
+
+func abs(x float32) float32 {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
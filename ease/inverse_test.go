@@ -0,0 +1,92 @@
+package ease
+
+import (
+	"math"
+	"testing"
+)
+
+func TestInverse(t *testing.T) {
+	const b, c, d = float32(2), float32(3), float32(4)
+	for name, fn := range byName {
+		t.Run(name, func(t *testing.T) {
+			for i := 1; i < 10; i++ {
+				value := b + c*float32(i)/10
+				times := Inverse(fn, value, b, c, d)
+				if len(times) == 0 {
+					t.Fatalf("no crossing found for %v", value)
+				}
+				for j, tm := range times {
+					if tm < 0 || tm > d {
+						t.Fatalf("crossing %v out of range", tm)
+					}
+					if j > 0 && tm < times[j-1] {
+						t.Fatalf("crossings not sorted: %v", times)
+					}
+					// InOutExpo jumps slightly across its middle, which counts as a crossing
+					if got := fn(tm, b, c, d); math.Abs(float64(got-value)) > float64(c)*1e-3 {
+						t.Fatalf("at %v expected %v got %v", tm, value, got)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestInverse_ClosedForm(t *testing.T) {
+	tests := []struct {
+		fn    TweenFunc
+		value float32
+		time  float32
+	}{
+		{Linear, 5, 5},
+		{InQuad, 2.5, 5},
+		{OutQuad, 7.5, 5},
+		{InOutCubic, 5, 5},
+		{OutInQuart, 10, 10},
+		{InSine, 10, 10},
+		{OutCirc, 0, 0},
+	}
+	for _, test := range tests {
+		times := Inverse(test.fn, test.value, 0, 10, 10)
+		if len(times) != 1 || math.Abs(float64(times[0]-test.time)) > 1e-4 {
+			t.Fatalf("expected [%v] got %v", test.time, times)
+		}
+	}
+	if times := Inverse(InQuad, 11, 0, 10, 10); times != nil {
+		t.Fatalf("expected no crossing got %v", times)
+	}
+}
+
+func TestInverse_NonMonotonic(t *testing.T) {
+	// OutBack overshoots past its end before settling back onto it
+	times := Inverse(OutBack, 1.05, 0, 1, 1)
+	if len(times) != 2 {
+		t.Fatalf("expected 2 crossings got %v", times)
+	}
+	// InBack dips below its beginning before heading to the end
+	times = Inverse(InBack, -0.05, 0, 1, 1)
+	if len(times) != 2 {
+		t.Fatalf("expected 2 crossings got %v", times)
+	}
+	// OutElastic wobbles across its end several times
+	times = Inverse(OutElastic, 1, 0, 1, 1)
+	if len(times) < 3 {
+		t.Fatalf("expected several crossings got %v", times)
+	}
+	if times := Inverse(OutBack, 2, 0, 1, 1); len(times) != 0 {
+		t.Fatalf("expected no crossing got %v", times)
+	}
+}
+
+func TestInverse_Custom(t *testing.T) {
+	wave := func(t, b, c, d float32) float32 {
+		return b + c*float32(math.Sin(float64(t/d*2*math.Pi)))
+	}
+	times := Inverse(wave, 0.5, 0, 1, 1)
+	if len(times) != 2 {
+		t.Fatalf("expected 2 crossings got %v", times)
+	}
+	if math.Abs(float64(times[0])-1.0/12) > 1e-4 || math.Abs(float64(times[1])-5.0/12) > 1e-4 {
+		t.Fatalf("unexpected crossings %v", times)
+	}
+}
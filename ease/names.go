@@ -0,0 +1,81 @@
+package ease
+
+import "sort"
+
+// byName maps the name of each built in easing function to the function itself.
+var byName = map[string]TweenFunc{
+	"Linear":       Linear,
+	"InQuad":       InQuad,
+	"OutQuad":      OutQuad,
+	"InOutQuad":    InOutQuad,
+	"OutInQuad":    OutInQuad,
+	"InCubic":      InCubic,
+	"OutCubic":     OutCubic,
+	"InOutCubic":   InOutCubic,
+	"OutInCubic":   OutInCubic,
+	"InQuart":      InQuart,
+	"OutQuart":     OutQuart,
+	"InOutQuart":   InOutQuart,
+	"OutInQuart":   OutInQuart,
+	"InQuint":      InQuint,
+	"OutQuint":     OutQuint,
+	"InOutQuint":   InOutQuint,
+	"OutInQuint":   OutInQuint,
+	"InSine":       InSine,
+	"OutSine":      OutSine,
+	"InOutSine":    InOutSine,
+	"OutInSine":    OutInSine,
+	"InExpo":       InExpo,
+	"OutExpo":      OutExpo,
+	"InOutExpo":    InOutExpo,
+	"OutInExpo":    OutInExpo,
+	"InCirc":       InCirc,
+	"OutCirc":      OutCirc,
+	"InOutCirc":    InOutCirc,
+	"OutInCirc":    OutInCirc,
+	"InElastic":    InElastic,
+	"OutElastic":   OutElastic,
+	"InOutElastic": InOutElastic,
+	"OutInElastic": OutInElastic,
+	"InBack":       InBack,
+	"OutBack":      OutBack,
+	"InOutBack":    InOutBack,
+	"OutInBack":    OutInBack,
+	"InBounce":     InBounce,
+	"OutBounce":    OutBounce,
+	"InOutBounce":  InOutBounce,
+	"OutInBounce":  OutInBounce,
+}
+
+// names maps each built in easing function back to its name.
+var names = map[uintptr]string{}
+
+func init() {
+	for name, fn := range byName {
+		names[funcKey(fn)] = name
+	}
+}
+
+// ByName returns the built in easing function with the given name, such as
+// "OutBounce", and whether it exists.
+func ByName(name string) (TweenFunc, bool) {
+	fn, ok := byName[name]
+	return fn, ok
+}
+
+// Name returns the name of a built in easing function, and false if fn is not
+// one of the built in easing functions.
+func Name(fn TweenFunc) (string, bool) {
+	name, ok := names[funcKey(fn)]
+	return name, ok
+}
+
+// Names returns the names of all built in easing functions in alphabetical order.
+func Names() []string {
+	all := make([]string, 0, len(byName))
+	for name := range byName {
+		all = append(all, name)
+	}
+	sort.Strings(all)
+	return all
+}
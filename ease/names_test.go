@@ -0,0 +1,25 @@
+package ease
+
+import "testing"
+
+func TestNames(t *testing.T) {
+	all := Names()
+	if len(all) != len(byName) {
+		t.Fatalf("expected %v names got %v", len(byName), len(all))
+	}
+	for name, fn := range byName {
+		found, ok := ByName(name)
+		if !ok || funcKey(found) != funcKey(fn) {
+			t.Fatalf("ByName(%q) did not return %s", name, name)
+		}
+		if got, ok := Name(fn); !ok || got != name {
+			t.Fatalf("Name(%s) returned %q", name, got)
+		}
+	}
+	if _, ok := ByName("InOutWobble"); ok {
+		t.Fatal("found an easing function that does not exist")
+	}
+	if _, ok := Name(func(t, b, c, d float32) float32 { return b }); ok {
+		t.Fatal("named a custom easing function")
+	}
+}
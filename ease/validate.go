@@ -0,0 +1,265 @@
+package ease
+
+import (
+	"fmt"
+	"math"
+)
+
+type (
+	// IssueKind is the kind of problem found by Validate.
+	IssueKind int
+
+	// Issue is a single problem found by Validate.
+	Issue struct {
+		Kind IssueKind
+		// T is the time at which the problem was found.
+		T float32
+		// Value is the value returned by the easing function at T.
+		Value float32
+		// Want is the value expected at T, for problems with a single expected value.
+		Want float32
+	}
+
+	// ValidateOptions configures Validate. The zero value checks endpoints,
+	// NaN/Inf output, continuity and zero durations with sensible defaults, but
+	// allows the function to overshoot and change direction.
+	ValidateOptions struct {
+		// B, C and D are the begin, change and duration the function is checked
+		// with. If D is not positive the function is checked from 0 to 1 over 1.
+		B, C, D float32
+		// Samples is the number of samples taken over the duration, 1000 if not
+		// positive.
+		Samples int
+		// Tolerance is how far, as a fraction of C, a value may be from where it
+		// is expected and still be considered correct, 1e-3 if not positive.
+		Tolerance float32
+		// RequireMonotonic reports an issue if the function ever moves back from
+		// the furthest it has been in the direction of C.
+		RequireMonotonic bool
+		// CheckOvershoot reports an issue if the function goes past B or B+C by
+		// more than MaxOvershoot, as a fraction of C.
+		CheckOvershoot bool
+		MaxOvershoot   float32
+	}
+
+	// Report is the result of Validate. Besides the list of issues it describes
+	// the shape of the function over the sampled grid.
+	Report struct {
+		Issues []Issue
+		// Min and Max are the smallest and largest values sampled.
+		Min, Max float32
+		// Monotonic is whether the function never moves back from the furthest
+		// it has been in the direction of C, so functions that overshoot are not.
+		Monotonic bool
+		// Overshoot is the furthest the function goes past B or B+C, as a
+		// fraction of C.
+		Overshoot float32
+	}
+)
+
+const (
+	// IssueStart means the function does not return B at time 0.
+	IssueStart IssueKind = iota
+	// IssueEnd means the function does not return B+C at time D.
+	IssueEnd
+	// IssueNotFinite means the function returned NaN or an infinity.
+	IssueNotFinite
+	// IssueNotMonotonic means the function moved back from the furthest it had
+	// been in the direction of C while RequireMonotonic was set.
+	IssueNotMonotonic
+	// IssueOvershoot means the function went further past B or B+C than allowed
+	// while CheckOvershoot was set.
+	IssueOvershoot
+	// IssueDiscontinuity means the function jumps between two values.
+	IssueDiscontinuity
+	// IssueZeroDuration means the function returned NaN or an infinity when
+	// called with a duration of 0.
+	IssueZeroDuration
+)
+
+var issueKindNames = map[IssueKind]string{
+	IssueStart:         "start",
+	IssueEnd:           "end",
+	IssueNotFinite:     "not finite",
+	IssueNotMonotonic:  "not monotonic",
+	IssueOvershoot:     "overshoot",
+	IssueDiscontinuity: "discontinuity",
+	IssueZeroDuration:  "zero duration",
+}
+
+func (kind IssueKind) String() string {
+	if name, ok := issueKindNames[kind]; ok {
+		return name
+	}
+	return fmt.Sprintf("IssueKind(%d)", int(kind))
+}
+
+func (issue Issue) String() string {
+	switch issue.Kind {
+	case IssueStart, IssueEnd, IssueOvershoot:
+		return fmt.Sprintf("%v: got %v at t=%v, want %v", issue.Kind, issue.Value, issue.T, issue.Want)
+	case IssueDiscontinuity:
+		return fmt.Sprintf("%v: jumps from %v to %v at t=%v", issue.Kind, issue.Want, issue.Value, issue.T)
+	}
+	return fmt.Sprintf("%v: got %v at t=%v", issue.Kind, issue.Value, issue.T)
+}
+
+// OK returns whether no issues were found.
+func (report Report) OK() bool {
+	return len(report.Issues) == 0
+}
+
+// Has returns whether an issue of kind was found.
+func (report Report) Has(kind IssueKind) bool {
+	for _, issue := range report.Issues {
+		if issue.Kind == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate checks that the easing function fn follows the rules every easing
+// function is expected to follow, over a grid of samples. It checks that fn
+// returns b at time 0 and b+c at time d, never returns NaN or an infinity, is
+// continuous, and copes with a duration of 0, and optionally that it never
+// changes direction or overshoots. Each kind of issue is reported at most once,
+// where it was first found.
+func Validate(fn TweenFunc, opts ValidateOptions) Report {
+	b, c, d := opts.B, opts.C, opts.D
+	if d <= 0 {
+		b, c, d = 0, 1, 1
+	}
+	samples := opts.Samples
+	if samples <= 0 {
+		samples = 1000
+	}
+	scale := abs(c)
+	if scale == 0 {
+		scale = 1
+	}
+	tolerance := opts.Tolerance
+	if tolerance <= 0 {
+		tolerance = 1e-3
+	}
+	tolerance *= scale
+
+	report := Report{Monotonic: true}
+	add := func(issue Issue) {
+		if !report.Has(issue.Kind) {
+			report.Issues = append(report.Issues, issue)
+		}
+	}
+
+	if start := fn(0, b, c, d); !finite(start) || abs(start-b) > tolerance {
+		add(Issue{Kind: IssueStart, T: 0, Value: start, Want: b})
+	}
+	if end := fn(d, b, c, d); !finite(end) || abs(end-(b+c)) > tolerance {
+		add(Issue{Kind: IssueEnd, T: d, Value: end, Want: b + c})
+	}
+	if value := fn(0, b, c, 0); !finite(value) {
+		add(Issue{Kind: IssueZeroDuration, T: 0, Value: value})
+	}
+
+	low, high := b, b+c
+	if low > high {
+		low, high = high, low
+	}
+	report.Min, report.Max = float32(math.Inf(1)), float32(math.Inf(-1))
+	var previous float32
+	// furthest is the furthest value sampled in the direction of c, so that a
+	// slow drift back is caught even when every step is within the tolerance
+	var furthest float32
+	reached := false
+	for i := 0; i <= samples; i++ {
+		t := d * float32(i) / float32(samples)
+		value := fn(t, b, c, d)
+		if !finite(value) {
+			add(Issue{Kind: IssueNotFinite, T: t, Value: value})
+			continue
+		}
+		if value < report.Min {
+			report.Min = value
+		}
+		if value > report.Max {
+			report.Max = value
+		}
+		if over := (value - high) / scale; over > report.Overshoot {
+			report.Overshoot = over
+		}
+		if under := (low - value) / scale; under > report.Overshoot {
+			report.Overshoot = under
+		}
+		switch {
+		case !reached || (value-furthest)*sign(c) > 0:
+			furthest, reached = value, true
+		case (value-furthest)*sign(c) < -tolerance:
+			// moved back against the direction of c beyond the tolerance
+			report.Monotonic = false
+			if opts.RequireMonotonic {
+				add(Issue{Kind: IssueNotMonotonic, T: t, Value: value})
+			}
+		}
+		if i > 0 && finite(previous) {
+			if abs(value-previous) > tolerance {
+				if at, from, to, jumped := findJump(fn, t-d/float32(samples), t, b, c, d, tolerance); jumped {
+					add(Issue{Kind: IssueDiscontinuity, T: at, Value: to, Want: from})
+				}
+			}
+		}
+		previous = value
+	}
+	if opts.CheckOvershoot && report.Overshoot > opts.MaxOvershoot {
+		t, value, want := overshootAt(fn, b, c, d, samples, low, high, scale)
+		add(Issue{Kind: IssueOvershoot, T: t, Value: value, Want: want})
+	}
+	return report
+}
+
+// findJump narrows down the interval from t0 to t1 onto the largest change in
+// value, and reports whether the change is still larger than tolerance once the
+// interval is too small for a continuous function to change that much.
+func findJump(fn TweenFunc, t0, t1, b, c, d, tolerance float32) (t, from, to float32, jumped bool) {
+	v0, v1 := fn(t0, b, c, d), fn(t1, b, c, d)
+	for i := 0; i < 24; i++ {
+		mid := t0 + (t1-t0)/2
+		if mid <= t0 || mid >= t1 {
+			break
+		}
+		vm := fn(mid, b, c, d)
+		if abs(vm-v0) >= abs(v1-vm) {
+			t1, v1 = mid, vm
+		} else {
+			t0, v0 = mid, vm
+		}
+	}
+	return t1, v0, v1, abs(v1-v0) > tolerance
+}
+
+// overshootAt returns the sample that goes furthest past low or high, and the
+// bound it goes past.
+func overshootAt(fn TweenFunc, b, c, d float32, samples int, low, high, scale float32) (t, value, bound float32) {
+	var furthest float32
+	for i := 0; i <= samples; i++ {
+		ti := d * float32(i) / float32(samples)
+		v := fn(ti, b, c, d)
+		if (v-high)/scale > furthest {
+			furthest, t, value, bound = (v-high)/scale, ti, v, high
+		}
+		if (low-v)/scale > furthest {
+			furthest, t, value, bound = (low-v)/scale, ti, v, low
+		}
+	}
+	return t, value, bound
+}
+
+func finite(value float32) bool {
+	return !math.IsNaN(float64(value)) && !math.IsInf(float64(value), 0)
+}
+
+func sign(value float32) float32 {
+	if value < 0 {
+		return -1
+	}
+	return 1
+}
@@ -0,0 +1,126 @@
+package ease
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestValidate_Builtins(t *testing.T) {
+	for name, fn := range byName {
+		// InExpo ends 0.1% short of b+c, right on the edge of the default tolerance
+		for _, opts := range []ValidateOptions{{Tolerance: 2e-3}, {B: 10, C: -20, D: 3, Tolerance: 2e-3}} {
+			report := Validate(fn, opts)
+			for _, issue := range report.Issues {
+				// Most built ins divide by the duration and cannot cope with 0
+				if issue.Kind != IssueZeroDuration {
+					t.Errorf("%s %+v: %v", name, opts, issue)
+				}
+			}
+			// OutIn functions only overshoot the middle, which is still within range
+			overshoots := (strings.Contains(name, "Back") || strings.Contains(name, "Elastic")) && !strings.HasPrefix(name, "OutIn")
+			if overshoots != (report.Overshoot > 0.05) {
+				t.Errorf("%s %+v: unexpected overshoot of %v", name, opts, report.Overshoot)
+			}
+			// Back functions move back from the furthest they reach, either before
+			// setting off or after overshooting
+			wobbles := strings.Contains(name, "Back") || strings.Contains(name, "Bounce") || strings.Contains(name, "Elastic")
+			if wobbles == report.Monotonic {
+				t.Errorf("%s %+v: unexpected monotonic %v", name, opts, report.Monotonic)
+			}
+		}
+	}
+}
+
+func TestValidate_ZeroDuration(t *testing.T) {
+	if report := Validate(Linear, ValidateOptions{}); !report.Has(IssueZeroDuration) || len(report.Issues) != 1 {
+		t.Fatalf("expected only a zero duration issue, got %v", report.Issues)
+	}
+	safe := func(t, b, c, d float32) float32 {
+		if d == 0 {
+			return b + c
+		}
+		return Linear(t, b, c, d)
+	}
+	if report := Validate(safe, ValidateOptions{}); !report.OK() {
+		t.Fatalf("expected no issues, got %v", report.Issues)
+	}
+}
+
+func TestValidate_Endpoints(t *testing.T) {
+	backwards := func(t, b, c, d float32) float32 { return b + c - c*t/d }
+	report := Validate(backwards, ValidateOptions{D: 2, C: 4})
+	if !report.Has(IssueStart) || !report.Has(IssueEnd) {
+		t.Fatalf("expected endpoint issues, got %v", report.Issues)
+	}
+	if issue := report.Issues[0]; issue.Kind != IssueStart || issue.Value != 4 || issue.Want != 0 {
+		t.Fatalf("unexpected start issue %v", issue)
+	}
+	if report.Monotonic {
+		t.Fatal("expected a function running backwards not to be monotonic")
+	}
+}
+
+func TestValidate_NotFinite(t *testing.T) {
+	blowsUp := func(t, b, c, d float32) float32 {
+		if t > d/2 && t < d {
+			return float32(math.Inf(1))
+		}
+		return Linear(t, b, c, d)
+	}
+	report := Validate(blowsUp, ValidateOptions{})
+	if !report.Has(IssueNotFinite) {
+		t.Fatalf("expected a not finite issue, got %v", report.Issues)
+	}
+	if report.Max != 1 {
+		t.Fatalf("expected infinite values to be ignored, got max %v", report.Max)
+	}
+}
+
+func TestValidate_Discontinuity(t *testing.T) {
+	step := func(t, b, c, d float32) float32 {
+		if t < d*0.3 {
+			return Linear(t, b, c/2, d)
+		}
+		return Linear(t, b+c/4, c*3/4, d)
+	}
+	report := Validate(step, ValidateOptions{})
+	if len(report.Issues) != 2 || !report.Has(IssueDiscontinuity) {
+		t.Fatalf("expected a discontinuity, got %v", report.Issues)
+	}
+	issue := report.Issues[1]
+	if abs(issue.T-0.3) > 1e-3 || abs(issue.Value-issue.Want-0.325) > 1e-3 {
+		t.Fatalf("unexpected discontinuity %v", issue)
+	}
+	// steep but continuous functions are not discontinuities
+	if report := Validate(InOutQuint, ValidateOptions{Samples: 10, D: 1}); report.Has(IssueDiscontinuity) {
+		t.Fatalf("unexpected discontinuity %v", report.Issues)
+	}
+}
+
+func TestValidate_Options(t *testing.T) {
+	report := Validate(OutBack, ValidateOptions{CheckOvershoot: true, MaxOvershoot: 0.05, RequireMonotonic: true})
+	if !report.Has(IssueOvershoot) || !report.Has(IssueNotMonotonic) {
+		t.Fatalf("unexpected issues %v", report.Issues)
+	}
+	for _, issue := range report.Issues {
+		if issue.Kind == IssueOvershoot && (issue.Want != 1 || issue.Value < 1.09) {
+			t.Fatalf("unexpected overshoot issue %v", issue)
+		}
+	}
+	if report := Validate(OutBack, ValidateOptions{CheckOvershoot: true, MaxOvershoot: 0.2}); report.Has(IssueOvershoot) {
+		t.Fatalf("unexpected issues %v", report.Issues)
+	}
+	if report := Validate(OutBounce, ValidateOptions{RequireMonotonic: true}); !report.Has(IssueNotMonotonic) {
+		t.Fatalf("expected OutBounce not to be monotonic, got %v", report.Issues)
+	}
+	// InBack only ever takes small steps back, but ends up well behind where it
+	// started
+	report = Validate(InBack, ValidateOptions{RequireMonotonic: true, Samples: 10000})
+	if !report.Has(IssueNotMonotonic) {
+		t.Fatalf("expected InBack not to be monotonic, got %v", report.Issues)
+	}
+	if IssueOvershoot.String() != "overshoot" || IssueKind(42).String() != "IssueKind(42)" {
+		t.Fatal("unexpected issue kind names")
+	}
+}
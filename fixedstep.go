@@ -0,0 +1,85 @@
+package gween
+
+// FixedStep drives animations in fixed size ticks rather than by the variable dt
+// of each frame. Wall clock time passed to Update is accumulated and the
+// animations only ever advance by whole ticks of the same size, so their output
+// depends solely on the number of ticks elapsed, never on how the frame times
+// were chunked. This keeps lockstep simulations bit-identical across machines.
+type FixedStep struct {
+	Animations []Animation
+	step       float32
+	// accumulator holds the time not yet consumed by a tick. It is a float64 to
+	// keep rounding errors small, although they still build up over long runs.
+	accumulator float64
+	ticks       uint64
+	previous    []float32
+}
+
+// NewFixedStep returns a new FixedStep advancing the provided animations by step
+// on every tick.
+func NewFixedStep(step float32, animations ...Animation) *FixedStep {
+	fs := &FixedStep{step: step}
+	fs.Add(animations...)
+	return fs
+}
+
+// Add adds one or more animations to be advanced on every tick.
+func (fs *FixedStep) Add(animations ...Animation) {
+	fs.Animations = append(fs.Animations, animations...)
+	for _, animation := range animations {
+		fs.previous = append(fs.previous, animation.Value())
+	}
+}
+
+// Update accumulates dt and advances the animations by as many whole ticks as
+// have elapsed, returning the number of ticks. Any remaining time is carried over
+// to the next Update.
+func (fs *FixedStep) Update(dt float32) (ticks int) {
+	if fs.step <= 0 {
+		return 0
+	}
+	fs.accumulator += float64(dt)
+	for fs.accumulator >= float64(fs.step) {
+		fs.accumulator -= float64(fs.step)
+		fs.Tick()
+		ticks++
+	}
+	return ticks
+}
+
+// Tick advances every animation by a single step, regardless of accumulated time.
+func (fs *FixedStep) Tick() {
+	for i, animation := range fs.Animations {
+		fs.previous[i] = animation.Value()
+		animation.step(fs.step)
+	}
+	fs.ticks++
+}
+
+// Ticks returns the total number of ticks elapsed.
+func (fs *FixedStep) Ticks() uint64 {
+	return fs.ticks
+}
+
+// Step returns the time each tick advances the animations by.
+func (fs *FixedStep) Step() float32 {
+	return fs.step
+}
+
+// Alpha returns how far the accumulated time is towards the next tick, between 0
+// and 1. It is used to interpolate rendering between the last two ticks.
+func (fs *FixedStep) Alpha() float32 {
+	if fs.step <= 0 {
+		return 0
+	}
+	return float32(fs.accumulator / float64(fs.step))
+}
+
+// Interpolated returns the value of the animation at index blended between its
+// value at the previous tick and the current tick by Alpha, for smooth rendering
+// between ticks. The simulation itself should always use the value of the
+// animation directly.
+func (fs *FixedStep) Interpolated(index int) float32 {
+	previous, current := fs.previous[index], fs.Animations[index].Value()
+	return previous + (current-previous)*fs.Alpha()
+}
@@ -0,0 +1,79 @@
+package gween
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tanema/gween/ease"
+)
+
+func newFixedStepSequence() *Sequence {
+	seq := NewSequence(
+		New(0, 1, 0.7, ease.OutElastic),
+		New(1, -3, 1.3, ease.InOutBack),
+		NewBy(2, 0.9, ease.OutBounce),
+	)
+	seq.SetYoyo(true)
+	seq.SetLoop(-1)
+	return seq
+}
+
+func TestFixedStep_Update(t *testing.T) {
+	tween := New(0, 10, 10, ease.Linear)
+	fs := NewFixedStep(0.5, tween)
+	assert.Equal(t, 0, fs.Update(0.25))
+	assert.Equal(t, float32(0), tween.Value())
+	assert.Equal(t, float32(0.5), fs.Alpha())
+	assert.Equal(t, 2, fs.Update(0.875))
+	assert.Equal(t, float32(1), tween.Value())
+	assert.Equal(t, uint64(2), fs.Ticks())
+	assert.Equal(t, float32(0.25), fs.Alpha())
+	assert.Equal(t, float32(0.625), fs.Interpolated(0))
+}
+
+func TestFixedStep_ChunkingIndependent(t *testing.T) {
+	const step = float32(1) / 60
+	rng := rand.New(rand.NewSource(42))
+
+	// Frame times that are whole multiples of 1/1024 add up exactly however they
+	// are chunked, so every run covers the same total time.
+	var frames []float32
+	for i := 0; i < 2000; i++ {
+		frames = append(frames, float32(rng.Intn(40))/1024)
+	}
+	chunked := func(chunk int) (*FixedStep, *Sequence) {
+		seq := newFixedStepSequence()
+		fs := NewFixedStep(step, seq)
+		for i := 0; i < len(frames); i += chunk {
+			var dt float32
+			for j := i; j < i+chunk && j < len(frames); j++ {
+				dt += frames[j]
+			}
+			fs.Update(dt)
+		}
+		return fs, seq
+	}
+
+	expectedFS, expected := chunked(1)
+	for _, chunk := range []int{2, 3, 7, 50, len(frames)} {
+		fs, seq := chunked(chunk)
+		assert.Equal(t, expectedFS.Ticks(), fs.Ticks())
+		assert.Equal(t, expected.Snapshot(), seq.Snapshot())
+		assert.Equal(t, expected.Value(), seq.Value())
+	}
+
+	// Which is identical to stepping directly by the tick size
+	direct := newFixedStepSequence()
+	for i := uint64(0); i < expectedFS.Ticks(); i++ {
+		direct.Update(step)
+	}
+	assert.Equal(t, expected.Snapshot(), direct.Snapshot())
+}
+
+func TestFixedStep_InvalidStep(t *testing.T) {
+	fs := NewFixedStep(0, New(0, 1, 1, ease.Linear))
+	assert.Equal(t, 0, fs.Update(1))
+	assert.Equal(t, float32(0), fs.Alpha())
+}
@@ -0,0 +1,183 @@
+package gween
+
+// Animation is implemented by both Tween and Sequence so that they can be
+// controlled together within a Group.
+type Animation interface {
+	// Value returns the current value without advancing the animation.
+	Value() float32
+	// Complete jumps the animation to its final state.
+	Complete()
+	// Seek moves the animation to time measured from its beginning.
+	Seek(time float32)
+	// Pause stops the animation from advancing until Resume is called.
+	Pause()
+	// Resume allows a paused animation to advance again.
+	Resume()
+	// Paused returns whether the animation is currently paused.
+	Paused() bool
+	// SetTimeScale sets the multiplier applied to every dt.
+	SetTimeScale(scale float32)
+	// TimeScale returns the multiplier applied to every dt.
+	TimeScale() float32
+	// ID returns the id of the animation.
+	ID() string
+	// Tags returns the tags of the animation.
+	Tags() []string
+	// HasTag returns whether the animation has been tagged with tag.
+	HasTag(tag string) bool
+
+	matches(selector string) bool
+	step(dt float32) bool
+	visit(fn func(Animation))
+}
+
+// Group is a collection of Tweens and Sequences that are updated together and
+// can be controlled in bulk by id or tag. Operations that take a selector apply
+// to every animation whose id equals the selector or that has been tagged with
+// it, including Tweens nested inside the Group's Sequences.
+type Group struct {
+	Animations []Animation
+	// autoRelease returns animations removed by Update to the pool
+	autoRelease bool
+	// dispatch, when set, is passed the callbacks of Sequences stepped by Update
+	// and Seek to call later
+	dispatch func(fn func())
+}
+
+// NewGroup returns a new Group containing the provided animations.
+func NewGroup(animations ...Animation) *Group {
+	return &Group{Animations: animations}
+}
+
+// Add adds one or more animations to the Group.
+func (group *Group) Add(animations ...Animation) {
+	group.Animations = append(group.Animations, animations...)
+}
+
+// Len returns the number of animations in the Group.
+func (group *Group) Len() int {
+	return len(group.Animations)
+}
+
+// Update advances every animation in the Group by dt. Animations that complete
+// are removed from the Group. Update returns true once the Group is empty.
+func (group *Group) Update(dt float32) (isFinished bool) {
+	remaining := group.Animations[:0]
+	for _, animation := range group.Animations {
+		seq, _ := animation.(*Sequence)
+		if seq != nil {
+			seq.dispatch = group.dispatch
+		}
+		finished := animation.step(dt)
+		if seq != nil {
+			seq.dispatch = nil
+		}
+		if !finished {
+			remaining = append(remaining, animation)
+		} else if group.autoRelease {
+			release(animation)
+		}
+	}
+	for i := len(remaining); i < len(group.Animations); i++ {
+		group.Animations[i] = nil
+	}
+	group.Animations = remaining
+	return len(group.Animations) == 0
+}
+
+// SetAutoRelease sets whether animations that complete during Update are
+// released back to the pool once they are removed, as with Release and
+// ReleaseSequence. Animations must not be used after they complete while this is
+// enabled. Animations removed by Kill are never released.
+func (group *Group) SetAutoRelease(autoRelease bool) {
+	group.autoRelease = autoRelease
+}
+
+// AutoRelease returns whether animations that complete during Update are
+// released back to the pool.
+func (group *Group) AutoRelease() bool {
+	return group.autoRelease
+}
+
+// Find returns every animation matching selector, including Tweens nested inside
+// the Group's Sequences.
+func (group *Group) Find(selector string) []Animation {
+	var found []Animation
+	group.each(selector, func(animation Animation) {
+		found = append(found, animation)
+	})
+	return found
+}
+
+// Pause pauses every animation matching selector.
+func (group *Group) Pause(selector string) {
+	group.each(selector, Animation.Pause)
+}
+
+// Resume resumes every animation matching selector.
+func (group *Group) Resume(selector string) {
+	group.each(selector, Animation.Resume)
+}
+
+// Complete jumps every animation matching selector to its final state. Completed
+// animations are removed from the Group on the next Update.
+func (group *Group) Complete(selector string) {
+	group.each(selector, Animation.Complete)
+}
+
+// Seek moves every animation matching selector to time, measured from its
+// beginning.
+func (group *Group) Seek(selector string, time float32) {
+	group.each(selector, func(animation Animation) {
+		seq, _ := animation.(*Sequence)
+		if seq != nil {
+			seq.dispatch = group.dispatch
+		}
+		animation.Seek(time)
+		if seq != nil {
+			seq.dispatch = nil
+		}
+	})
+}
+
+// SetTimeScale sets the time scale of every animation matching selector.
+func (group *Group) SetTimeScale(selector string, scale float32) {
+	group.each(selector, func(animation Animation) {
+		animation.SetTimeScale(scale)
+	})
+}
+
+// Kill immediately removes every animation matching selector from the Group,
+// leaving it at its current value. Matching Tweens nested inside a Sequence are
+// removed from that Sequence.
+func (group *Group) Kill(selector string) {
+	remaining := group.Animations[:0]
+	for _, animation := range group.Animations {
+		if animation.matches(selector) {
+			continue
+		}
+		if seq, ok := animation.(*Sequence); ok {
+			for i := len(seq.Tweens) - 1; i >= 0; i-- {
+				if seq.Tweens[i].matches(selector) {
+					seq.Remove(i)
+				}
+			}
+		}
+		remaining = append(remaining, animation)
+	}
+	for i := len(remaining); i < len(group.Animations); i++ {
+		group.Animations[i] = nil
+	}
+	group.Animations = remaining
+}
+
+// each calls fn for every animation matching selector.
+func (group *Group) each(selector string, fn func(Animation)) {
+	for _, animation := range group.Animations {
+		animation.visit(func(animation Animation) {
+			if animation.matches(selector) {
+				fn(animation)
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package gween
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tanema/gween/ease"
+)
+
+func TestLabels(t *testing.T) {
+	tween := New(0, 1, 1, ease.Linear)
+	tween.SetID("player")
+	tween.Tag("ui", "hud", "ui")
+	assert.Equal(t, "player", tween.ID())
+	assert.Equal(t, []string{"ui", "hud"}, tween.Tags())
+	assert.True(t, tween.HasTag("hud"))
+	assert.True(t, tween.matches("player"))
+	assert.True(t, tween.matches("ui"))
+	assert.False(t, tween.matches(""))
+	tween.Untag("ui")
+	assert.False(t, tween.HasTag("ui"))
+	assert.Equal(t, []string{"hud"}, tween.Tags())
+}
+
+func TestTween_Pause(t *testing.T) {
+	tween := New(0, 10, 10, ease.Linear)
+	tween.Update(2)
+	tween.Pause()
+	assert.True(t, tween.Paused())
+	current, isFinished := tween.Update(5)
+	assert.Equal(t, float32(2), current)
+	assert.False(t, isFinished)
+	tween.Resume()
+	current, _ = tween.Update(5)
+	assert.Equal(t, float32(7), current)
+}
+
+func TestTween_TimeScale(t *testing.T) {
+	tween := New(0, 10, 10, ease.Linear)
+	tween.SetTimeScale(2)
+	current, isFinished := tween.Update(2)
+	assert.Equal(t, float32(4), current)
+	assert.False(t, isFinished)
+	current, isFinished = tween.Update(4)
+	assert.Equal(t, float32(10), current)
+	assert.Equal(t, float32(2), tween.Overflow)
+	assert.True(t, isFinished)
+}
+
+func TestTween_Complete(t *testing.T) {
+	tween := New(0, 10, 10, ease.Linear)
+	tween.Update(2)
+	tween.Complete()
+	assert.Equal(t, float32(10), tween.Value())
+	tween.reverse = true
+	tween.Complete()
+	assert.Equal(t, float32(0), tween.Value())
+}
+
+func TestSequence_TimeScaledTween(t *testing.T) {
+	fast := New(0, 1, 1, ease.Linear)
+	fast.SetTimeScale(2)
+	seq := NewSequence(fast, New(1, 2, 1, ease.Linear))
+	current, finishedTween, seqFinished := seq.Update(1)
+	// 0.5 is spent on the fast tween, the remaining 0.5 carries over
+	assert.Equal(t, float32(1.5), current)
+	assert.True(t, finishedTween)
+	assert.False(t, seqFinished)
+}
+
+func TestSequence_Complete(t *testing.T) {
+	seq := NewSequence(New(0, 1, 1, ease.Linear), New(1, 2, 1, ease.Linear))
+	seq.SetLoop(3)
+	seq.Update(0.5)
+	seq.Complete()
+	assert.Equal(t, float32(2), seq.Value())
+	current, _, seqFinished := seq.Update(1)
+	assert.Equal(t, float32(2), current)
+	assert.True(t, seqFinished)
+
+	seq = NewSequence(New(0, 1, 1, ease.Linear), New(1, 2, 1, ease.Linear))
+	seq.SetYoyo(true)
+	seq.Update(1.5)
+	seq.Complete()
+	assert.Equal(t, float32(0), seq.Value())
+	current, _, seqFinished = seq.Update(1)
+	assert.Equal(t, float32(0), current)
+	assert.True(t, seqFinished)
+}
+
+func TestGroup_Update(t *testing.T) {
+	short := New(0, 1, 1, ease.Linear)
+	long := New(0, 1, 2, ease.Linear)
+	group := NewGroup(short, long)
+	assert.False(t, group.Update(1))
+	assert.Equal(t, 1, group.Len())
+	assert.Equal(t, float32(1), short.Value())
+	assert.Equal(t, float32(0.5), long.Value())
+	assert.True(t, group.Update(1))
+	assert.Equal(t, 0, group.Len())
+}
+
+func TestGroup_PauseNestedSequence(t *testing.T) {
+	menu := New(0, 1, 1, ease.Linear)
+	menu.Tag("ui")
+	seq := NewSequence(New(0, 1, 1, ease.Linear), menu)
+	other := New(0, 10, 10, ease.Linear)
+	group := NewGroup(seq, other)
+
+	group.Pause("ui")
+	assert.True(t, menu.Paused())
+	assert.False(t, seq.Paused())
+	assert.False(t, other.Paused())
+	assert.Equal(t, []Animation{menu}, group.Find("ui"))
+
+	// The sequence holds on its paused tween
+	group.Update(1.5)
+	assert.Equal(t, float32(0), seq.Value())
+	assert.Equal(t, float32(1.5), other.Value())
+
+	group.Resume("ui")
+	group.Update(0.5)
+	assert.Equal(t, float32(0.5), seq.Value())
+}
+
+func TestGroup_TimeScaleAndComplete(t *testing.T) {
+	seq := NewSequence(New(0, 1, 1, ease.Linear), New(1, 2, 1, ease.Linear))
+	seq.Tag("fx")
+	tween := New(0, 10, 10, ease.Linear)
+	tween.SetID("enemy")
+	group := NewGroup(seq, tween)
+
+	group.SetTimeScale("fx", 2)
+	group.Update(0.5)
+	assert.Equal(t, float32(1), seq.Value())
+	assert.Equal(t, float32(0.5), tween.Value())
+
+	group.Complete("enemy")
+	assert.Equal(t, float32(10), tween.Value())
+	group.Update(0)
+	assert.Equal(t, []Animation{seq}, group.Animations)
+}
+
+func TestGroup_Kill(t *testing.T) {
+	doomed := New(0, 1, 1, ease.Linear)
+	doomed.SetID("entity")
+	nested := New(1, 2, 1, ease.Linear)
+	nested.SetID("entity")
+	seq := NewSequence(New(0, 1, 1, ease.Linear), nested)
+	group := NewGroup(doomed, seq)
+
+	group.Kill("entity")
+	assert.Equal(t, []Animation{seq}, group.Animations)
+	assert.Equal(t, 1, len(seq.Tweens))
+	assert.Empty(t, group.Find("entity"))
+}
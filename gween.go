@@ -3,9 +3,21 @@
 // the easing functions.
 package gween
 
-import "github.com/tanema/gween/ease"
+import (
+	"errors"
+	"math"
+
+	"github.com/tanema/gween/ease"
+)
+
+// ErrInvalidDuration is returned by NewChecked when a duration is negative, NaN or
+// infinite.
+var ErrInvalidDuration = errors.New("gween: invalid duration")
 
 type (
+	// Kind describes how the begin and end values of a Tween are determined.
+	Kind int
+
 	// Tween encapsulates the easing function along with timing data. This allows
 	// a ease.TweenFunc to be used to be easily animated.
 	Tween struct {
@@ -17,25 +29,194 @@ type (
 		Overflow float32
 		easing   ease.TweenFunc
 		reverse  bool
+		paused   bool
+		// timeScale multiplies every dt passed to Update
+		timeScale float32
+		kind      Kind
+		source    func() float32
+		resolved  bool
+		// blend is the velocity offset eased out over the duration after Retarget
+		blend float32
+		// callback is called when a Sequence passes a KindCallback Tween
+		callback func()
+		// loop is the number of times to play the Tween, and loopRemaining the
+		// number left including the current one
+		loop          int
+		loopRemaining int
+		loopMode      LoopMode
+		// backEasing is used on the way back of a ping-pong, defaulting to easing
+		backEasing ease.TweenFunc
+		// returning is whether a yoyo or ping-pong Tween is on its way back
+		returning bool
+		// mirrored plays the back easing forward in time while running in reverse,
+		// rather than playing the easing backwards
+		mirrored bool
+		// shift is how far incremental loops have moved begin and end
+		shift float32
+		// completedLoops is the number of loops completed, not counting the last
+		completedLoops int
+		labels
 	}
 )
 
+const (
+	// KindAbsolute Tweens are created with New and have fixed begin and end values.
+	KindAbsolute Kind = iota
+	// KindBy Tweens are created with NewBy and move by a delta from the current value.
+	KindBy
+	// KindFrom Tweens are created with NewFrom and animate from a value to the current value.
+	KindFrom
+	// KindDelay Tweens are created with NewDelay and hold the current value.
+	KindDelay
+	// KindCallback Tweens are created with NewCallback and call a function when passed.
+	KindCallback
+)
+
 // New will return a new Tween when passed a beginning and end value, the duration
 // of the tween and the easing function to animate between the two values. The
 // easing function can be one of the provided easing functions from the ease package
 // or you can provide one of your own.
+//
+// A duration of 0 creates an instant Tween, which is always complete and whose
+// value is always its end, or its begin when reversed. Negative and NaN durations
+// are treated as 0; use NewChecked to reject them instead.
 func New(begin, end, duration float32, easing ease.TweenFunc) *Tween {
-	return &Tween{
+	tween := &Tween{}
+	tween.setup(begin, end, duration, easing)
+	return tween
+}
+
+// NewChecked returns a new Tween like New, but returns ErrInvalidDuration rather
+// than a Tween if duration is negative, NaN or infinite.
+func NewChecked(begin, end, duration float32, easing ease.TweenFunc) (*Tween, error) {
+	if duration < 0 || math.IsNaN(float64(duration)) || math.IsInf(float64(duration), 0) {
+		return nil, ErrInvalidDuration
+	}
+	return New(begin, end, duration, easing), nil
+}
+
+// setup sets every field of the Tween to that of a new Tween, reusing the memory
+// of its tags.
+func (tween *Tween) setup(begin, end, duration float32, easing ease.TweenFunc) {
+	*tween = Tween{
 		begin:    begin,
 		end:      end,
 		change:   end - begin,
-		duration: duration,
+		duration: validDuration(duration),
 		easing:   easing,
 		Overflow: 0,
 		reverse:  false,
+
+		timeScale:     1,
+		resolved:      true,
+		loop:          1,
+		loopRemaining: 1,
+		labels:        labels{tags: tween.tags[:0]},
 	}
 }
 
+// NewBy returns a relative Tween that moves by delta from whatever the current
+// value is when it first updates. Inside a Sequence the current value is the end
+// of the previous Tween, otherwise it is read from the function set with
+// SetSource, defaulting to 0.
+func NewBy(delta, duration float32, easing ease.TweenFunc) *Tween {
+	tween := New(0, delta, duration, easing)
+	tween.kind = KindBy
+	tween.resolved = false
+	return tween
+}
+
+// NewFrom returns a Tween that animates from the given value to whatever the
+// current value is when it first updates. Inside a Sequence the current value is
+// the end of the previous Tween, otherwise it is read from the function set with
+// SetSource, defaulting to from.
+func NewFrom(from, duration float32, easing ease.TweenFunc) *Tween {
+	tween := New(from, from, duration, easing)
+	tween.kind = KindFrom
+	tween.resolved = false
+	return tween
+}
+
+// NewDelay returns a Tween that waits for duration, holding whatever the current
+// value is when it first updates. Inside a Sequence the current value is the end
+// of the previous Tween, otherwise it is read from the function set with
+// SetSource, defaulting to 0.
+func NewDelay(duration float32) *Tween {
+	tween := New(0, 0, duration, ease.Linear)
+	tween.kind = KindDelay
+	tween.resolved = false
+	return tween
+}
+
+// NewCallback returns an instant Tween that calls fn whenever a Sequence passes
+// it, in either direction, and otherwise holds the current value like a delay
+// of 0. A Sequence that bounces off a callback when yoyoing calls it once. The
+// function is only called by a Sequence; updating the Tween on its own does not
+// call it.
+func NewCallback(fn func()) *Tween {
+	tween := NewDelay(0)
+	tween.kind = KindCallback
+	tween.callback = fn
+	return tween
+}
+
+// SetSource sets the function used to read the current value when a relative
+// Tween, created with NewBy, NewFrom, NewDelay or NewCallback, first updates
+// outside of a Sequence.
+func (tween *Tween) SetSource(source func() float32) {
+	tween.source = source
+}
+
+// Resolve fixes the begin and end values of a relative Tween, created with NewBy,
+// NewFrom, NewDelay or NewCallback, using current as the current value. It is
+// called automatically on the first Update, so it only needs to be called to
+// resolve the Tween ahead of time. It has no effect on absolute Tweens.
+func (tween *Tween) Resolve(current float32) {
+	switch tween.kind {
+	case KindBy:
+		tween.begin, tween.end = current, current+tween.change
+	case KindFrom:
+		tween.end = current
+		tween.change = tween.end - tween.begin
+	case KindDelay, KindCallback:
+		tween.begin, tween.end, tween.change = current, current, 0
+	}
+	tween.resolved = true
+}
+
+// Kind returns how the begin and end values of the Tween are determined.
+func (tween *Tween) Kind() Kind {
+	return tween.kind
+}
+
+// Begin returns the value the Tween starts from. For a KindFrom Tween this is the
+// from value, for an unresolved KindBy Tween it is 0.
+func (tween *Tween) Begin() float32 {
+	return tween.begin
+}
+
+// End returns the value the Tween finishes at. For an unresolved KindBy Tween this
+// is the delta, for an unresolved KindFrom Tween it is the from value.
+func (tween *Tween) End() float32 {
+	return tween.end
+}
+
+// Duration returns how long the Tween takes to complete.
+func (tween *Tween) Duration() float32 {
+	return tween.duration
+}
+
+// Easing returns the easing function of the Tween.
+func (tween *Tween) Easing() ease.TweenFunc {
+	return tween.easing
+}
+
+// Resolved returns whether the begin and end values of the Tween are known,
+// which is always the case for Tweens created with New.
+func (tween *Tween) Resolved() bool {
+	return tween.resolved
+}
+
 // Set will set the current time along the duration of the tween. It will then return
 // the current value as well as a boolean to determine if the tween is finished.
 func (tween *Tween) Set(time float32) (current float32, isFinished bool) {
@@ -43,25 +224,134 @@ func (tween *Tween) Set(time float32) (current float32, isFinished bool) {
 	case time <= 0:
 		tween.Overflow = time
 		tween.time = 0
-		current = tween.begin
 	case time >= tween.duration:
 		tween.Overflow = time - tween.duration
 		tween.time = tween.duration
-		current = tween.end
 	default:
 		tween.Overflow = 0
 		tween.time = time
-		current = tween.easing(tween.time, tween.begin, tween.change, tween.duration)
 	}
+	return tween.Value(), tween.finished()
+}
+
+// Seek sets the Tween to the state it would be in after being Reset and then
+// played for time, ignoring whether it is paused or time scaled.
+func (tween *Tween) Seek(time float32) {
+	tween.Reset()
+	tween.advance(time)
+}
+
+// Value returns the value of the Tween at its current time without advancing it.
+func (tween *Tween) Value() float32 {
+	if tween.duration <= 0 {
+		// Instant tweens are always complete
+		if tween.reverse {
+			return tween.begin
+		}
+		return tween.end
+	}
+	switch {
+	case tween.time <= 0:
+		return tween.begin
+	case tween.time >= tween.duration:
+		return tween.end
+	}
+	return tween.valueAt(tween.time)
+}
 
+// Velocity returns the rate at which the value of the Tween is changing at its
+// current time, per unit of the dt passed to Update. It takes the direction and
+// time scale of the Tween into account, and is 0 while the Tween is paused or
+// finished.
+func (tween *Tween) Velocity() float32 {
+	if tween.paused || tween.finished() {
+		return 0
+	}
+	velocity := tween.velocityAt(tween.time) * tween.timeScale
 	if tween.reverse {
-		return current, tween.time <= 0
+		return -velocity
+	}
+	return velocity
+}
+
+// Acceleration returns the rate at which the Velocity of the Tween is changing at
+// its current time, per unit of the dt passed to Update. It is 0 while the Tween
+// is paused or finished.
+func (tween *Tween) Acceleration() float32 {
+	if tween.paused || tween.finished() {
+		return 0
+	}
+	return tween.accelerationAt(tween.time) * tween.timeScale * tween.timeScale
+}
+
+// TimeAt returns every time within the duration of the Tween, in ascending order,
+// at which it reaches value. Tweens whose easing overshoots may reach the same
+// value several times, and every crossing is returned.
+func (tween *Tween) TimeAt(value float32) []float32 {
+	if tween.duration <= 0 {
+		if value == tween.end {
+			return []float32{0}
+		}
+		return nil
 	}
-	return current, tween.time >= tween.duration
+	easing := tween.easing
+	if tween.blend != 0 || tween.mirrored {
+		easing = func(t, b, c, d float32) float32 {
+			return tween.valueAt(t)
+		}
+	}
+	return ease.Inverse(easing, value, tween.begin, tween.change, tween.duration)
 }
 
-// Reset will set the Tween to the beginning of the two values.
+// Retarget changes the end value and duration of the Tween while it is running.
+// The Tween restarts from its current value and blends into the new curve so that
+// both its position and its velocity stay continuous, avoiding the visible kink
+// of replacing it with a new Tween. The retargeted Tween always runs forward.
+func (tween *Tween) Retarget(end, duration float32) {
+	var velocity float32
+	if !tween.finished() {
+		velocity = tween.velocityAt(tween.time)
+		if tween.reverse {
+			velocity = -velocity
+		}
+	}
+	tween.begin = tween.Value()
+	tween.end = end
+	tween.change = end - tween.begin
+	tween.duration = validDuration(duration)
+	tween.time = 0
+	tween.Overflow = 0
+	tween.reverse = false
+	tween.returning = false
+	tween.mirrored = false
+	tween.shift = 0
+	tween.kind = KindAbsolute
+	tween.resolved = true
+	tween.blend = 0
+	tween.blend = velocity - tween.velocityAt(0)
+}
+
+// Reset will set the Tween to the beginning of the two values, and to its first
+// loop.
 func (tween *Tween) Reset() {
+	if tween.returning {
+		tween.rewind(!tween.reverse)
+	} else {
+		tween.rewind(tween.reverse)
+	}
+}
+
+// rewind sets the Tween to the beginning of its first loop running in reverse,
+// or forward.
+func (tween *Tween) rewind(reverse bool) {
+	tween.begin -= tween.shift
+	tween.end -= tween.shift
+	tween.shift = 0
+	tween.loopRemaining = tween.loop
+	tween.completedLoops = 0
+	tween.returning = false
+	tween.mirrored = false
+	tween.reverse = reverse
 	if tween.reverse {
 		tween.Set(tween.duration)
 	} else {
@@ -72,8 +362,169 @@ func (tween *Tween) Reset() {
 // Update will increment the timer of the Tween and ease the value. It will then
 // return the current value as well as a bool to mark if the tween is finished or not.
 func (tween *Tween) Update(dt float32) (current float32, isFinished bool) {
+	if tween.paused {
+		tween.Overflow = 0
+		return tween.Value(), tween.finished()
+	}
+	return tween.advance(dt * tween.timeScale)
+}
+
+// advance moves the Tween along by dt in the direction it is heading, moving on
+// to its next loop whenever it finishes one.
+func (tween *Tween) advance(dt float32) (current float32, isFinished bool) {
+	if !tween.resolved {
+		tween.resolveSource()
+	}
 	if tween.reverse {
-		return tween.Set(tween.time - dt)
+		current, isFinished = tween.Set(tween.time - dt)
+	} else {
+		current, isFinished = tween.Set(tween.time + dt)
+	}
+	for isFinished && tween.looping() {
+		current, isFinished = tween.nextLoop()
 	}
-	return tween.Set(tween.time + dt)
+	return current, isFinished
+}
+
+// Complete jumps the Tween to its end, or to its beginning when it is running in
+// reverse, as if it had been updated to completion. Looping Tweens finish their
+// last loop, or their current loop if they loop forever.
+func (tween *Tween) Complete() {
+	switch tween.loopMode {
+	case LoopYoyo, LoopPingPong:
+		if !tween.returning {
+			tween.bounce()
+		}
+	case LoopIncremental:
+		if tween.loopRemaining > 1 {
+			tween.increment(float32(tween.loopRemaining - 1))
+		}
+	}
+	if tween.loopRemaining > 1 {
+		tween.completedLoops += tween.loopRemaining - 1
+		tween.loopRemaining = 1
+	}
+	if tween.reverse {
+		tween.Set(0)
+	} else {
+		tween.Set(tween.duration)
+	}
+}
+
+// Pause stops Update from advancing the Tween until Resume is called.
+func (tween *Tween) Pause() {
+	tween.paused = true
+}
+
+// Resume allows a paused Tween to advance again.
+func (tween *Tween) Resume() {
+	tween.paused = false
+}
+
+// Paused returns whether the Tween is currently paused.
+func (tween *Tween) Paused() bool {
+	return tween.paused
+}
+
+// SetTimeScale sets the multiplier applied to every dt passed to Update. A scale
+// of 2 plays the Tween twice as fast, 0.5 half as fast. Defaults to 1.
+func (tween *Tween) SetTimeScale(scale float32) {
+	tween.timeScale = scale
+}
+
+// TimeScale returns the multiplier applied to every dt passed to Update.
+func (tween *Tween) TimeScale() float32 {
+	return tween.timeScale
+}
+
+// valueAt returns the eased value at time, which must be within the duration.
+func (tween *Tween) valueAt(time float32) float32 {
+	if tween.mirrored {
+		if tween.reverse {
+			return tween.back()(tween.duration-time, tween.end, -tween.change, tween.duration)
+		}
+		return tween.back()(time, tween.begin, tween.change, tween.duration)
+	}
+	value := tween.easing(time, tween.begin, tween.change, tween.duration)
+	if tween.blend != 0 {
+		// s(1-s)^2 starts with a slope of 1 and flattens out to 0 at the end
+		s := time / tween.duration
+		value += tween.blend * tween.duration * s * (1 - s) * (1 - s)
+	}
+	return value
+}
+
+// velocityAt returns the rate of change of the eased value with respect to the
+// time of the Tween.
+func (tween *Tween) velocityAt(time float32) float32 {
+	if tween.duration <= 0 {
+		return 0
+	}
+	if tween.mirrored {
+		if tween.reverse {
+			return -ease.Velocity(tween.back(), tween.duration-time, tween.end, -tween.change, tween.duration)
+		}
+		return ease.Velocity(tween.back(), time, tween.begin, tween.change, tween.duration)
+	}
+	velocity := ease.Velocity(tween.easing, time, tween.begin, tween.change, tween.duration)
+	if tween.blend != 0 {
+		s := time / tween.duration
+		velocity += tween.blend * (1 - s) * (1 - 3*s)
+	}
+	return velocity
+}
+
+// accelerationAt returns the rate of change of velocityAt with respect to the
+// time of the Tween.
+func (tween *Tween) accelerationAt(time float32) float32 {
+	if tween.duration <= 0 {
+		return 0
+	}
+	if tween.mirrored {
+		if tween.reverse {
+			return ease.Acceleration(tween.back(), tween.duration-time, tween.end, -tween.change, tween.duration)
+		}
+		return ease.Acceleration(tween.back(), time, tween.begin, tween.change, tween.duration)
+	}
+	acceleration := ease.Acceleration(tween.easing, time, tween.begin, tween.change, tween.duration)
+	if tween.blend != 0 {
+		s := time / tween.duration
+		acceleration += tween.blend / tween.duration * (6*s - 4)
+	}
+	return acceleration
+}
+
+// resolveSource resolves the Tween against its source, or its begin value when it
+// has no source.
+func (tween *Tween) resolveSource() {
+	current := tween.begin
+	if tween.source != nil {
+		current = tween.source()
+	}
+	tween.Resolve(current)
+}
+
+// validDuration returns duration, or 0 if it is negative or NaN.
+func validDuration(duration float32) float32 {
+	if duration > 0 {
+		return duration
+	}
+	return 0
+}
+
+// finished returns whether the Tween has reached the end it is heading towards.
+func (tween *Tween) finished() bool {
+	if tween.reverse {
+		return tween.time <= 0
+	}
+	return tween.time >= tween.duration
+}
+
+func (tween *Tween) step(dt float32) bool {
+	_, isFinished := tween.Update(dt)
+	return isFinished
+}
+
+func (tween *Tween) visit(fn func(Animation)) {
+	fn(tween)
 }
@@ -1,6 +1,7 @@
 package gween
 
 import (
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -66,6 +67,27 @@ func TestTween_SetNegReverse(t *testing.T) {
 	assert.True(t, isFinished)
 }
 
+func TestTween_Seek(t *testing.T) {
+	tween := New(0, 10, 1, ease.Linear)
+	tween.SetLoopMode(LoopYoyo)
+	tween.SetLoop(2)
+	tween.SetTimeScale(2)
+	tween.Pause()
+	tween.Seek(3.5)
+	assert.Equal(t, float32(5), tween.Value())
+	assert.True(t, tween.Reverse())
+	assert.Equal(t, 1, tween.LoopsRemaining())
+
+	// Seeking back starts over from the beginning of the first loop
+	tween.Seek(0.25)
+	assert.Equal(t, float32(2.5), tween.Value())
+	assert.False(t, tween.Reverse())
+	assert.Equal(t, 2, tween.LoopsRemaining())
+	tween.Seek(5)
+	assert.Equal(t, float32(0), tween.Value())
+	assert.Equal(t, 0, tween.LoopsRemaining())
+}
+
 func TestTween_Reset(t *testing.T) {
 	tween := New(0, 10, 10, ease.Linear)
 	current, isFinished := tween.Set(2)
@@ -160,3 +182,210 @@ func TestTween_CanReverseFromStart(t *testing.T) {
 	assert.Equal(t, float32(0), current)
 	assert.Equal(t, float32(-1.0), tween.Overflow)
 }
+
+func TestNewBy(t *testing.T) {
+	position := float32(5)
+	tween := NewBy(10, 10, ease.Linear)
+	tween.SetSource(func() float32 { return position })
+	assert.False(t, tween.Resolved())
+	position = 7
+	current, isFinished := tween.Update(5)
+	assert.True(t, tween.Resolved())
+	assert.Equal(t, float32(12), current)
+	assert.False(t, isFinished)
+	position = 100
+	current, isFinished = tween.Update(5)
+	assert.Equal(t, float32(17), current)
+	assert.True(t, isFinished)
+}
+
+func TestNewBy_NoSource(t *testing.T) {
+	tween := NewBy(10, 10, ease.Linear)
+	current, _ := tween.Update(5)
+	assert.Equal(t, float32(5), current)
+}
+
+func TestNewFrom(t *testing.T) {
+	tween := NewFrom(20, 10, ease.Linear)
+	tween.SetSource(func() float32 { return 10 })
+	current, _ := tween.Update(0)
+	assert.Equal(t, float32(20), current)
+	current, isFinished := tween.Update(10)
+	assert.Equal(t, float32(10), current)
+	assert.True(t, isFinished)
+}
+
+func TestTween_Resolve(t *testing.T) {
+	tween := NewBy(-2, 1, ease.Linear)
+	tween.Resolve(3)
+	assert.Equal(t, float32(3), tween.begin)
+	assert.Equal(t, float32(1), tween.end)
+	assert.Equal(t, float32(-2), tween.change)
+
+	absolute := New(0, 1, 1, ease.Linear)
+	absolute.Resolve(3)
+	assert.Equal(t, float32(0), absolute.begin)
+	assert.Equal(t, float32(1), absolute.end)
+}
+
+// velocityAround measures the rate of change over a small step before and after
+// the current time of the tween, without disturbing it.
+func velocityAround(tween *Tween, h float32) (before, after float32) {
+	current := tween.Value()
+	previous := *tween
+	previous.reverse = !tween.reverse
+	past, _ := previous.Update(h)
+	next := *tween
+	future, _ := next.Update(h)
+	return (current - past) / h, (future - current) / h
+}
+
+func TestTween_Retarget(t *testing.T) {
+	easings := map[string]ease.TweenFunc{
+		"Linear":    ease.Linear,
+		"InQuad":    ease.InQuad,
+		"OutCubic":  ease.OutCubic,
+		"InOutSine": ease.InOutSine,
+	}
+	for name, easing := range easings {
+		t.Run(name, func(t *testing.T) {
+			tween := New(0, 10, 10, easing)
+			tween.Update(4)
+			position := tween.Value()
+			velocity, _ := velocityAround(tween, 0.01)
+
+			tween.Retarget(-5, 5)
+			assert.Equal(t, position, tween.Value())
+			_, retargeted := velocityAround(tween, 0.01)
+			assert.InDelta(t, velocity, retargeted, 0.05)
+
+			current, isFinished := tween.Update(5)
+			assert.Equal(t, float32(-5), current)
+			assert.True(t, isFinished)
+		})
+	}
+}
+
+func TestTween_RetargetReverse(t *testing.T) {
+	tween := New(0, 10, 10, ease.Linear)
+	tween.Update(6)
+	tween.reverse = true
+	tween.Retarget(20, 10)
+	assert.False(t, tween.reverse)
+	assert.Equal(t, float32(6), tween.Value())
+	_, velocity := velocityAround(tween, 0.01)
+	assert.InDelta(t, -1, velocity, 0.05)
+}
+
+func TestTween_RetargetFinished(t *testing.T) {
+	tween := New(0, 10, 10, ease.Linear)
+	tween.Update(10)
+	tween.Retarget(0, 10)
+	// A finished tween is at rest, so the new curve starts at rest too
+	_, velocity := velocityAround(tween, 0.01)
+	assert.InDelta(t, 0, velocity, 0.05)
+	current, isFinished := tween.Update(10)
+	assert.Equal(t, float32(0), current)
+	assert.True(t, isFinished)
+}
+
+func TestTween_Velocity(t *testing.T) {
+	tween := New(0, 10, 10, ease.InQuad)
+	tween.Update(5)
+	assert.InDelta(t, 1, tween.Velocity(), 1e-5)
+	assert.InDelta(t, 0.2, tween.Acceleration(), 1e-5)
+
+	tween.SetTimeScale(2)
+	assert.InDelta(t, 2, tween.Velocity(), 1e-5)
+	assert.InDelta(t, 0.8, tween.Acceleration(), 1e-5)
+
+	tween.reverse = true
+	assert.InDelta(t, -2, tween.Velocity(), 1e-5)
+	assert.InDelta(t, 0.8, tween.Acceleration(), 1e-5)
+
+	tween.Pause()
+	assert.Equal(t, float32(0), tween.Velocity())
+	assert.Equal(t, float32(0), tween.Acceleration())
+
+	tween = New(0, 10, 10, ease.Linear)
+	tween.Update(10)
+	assert.Equal(t, float32(0), tween.Velocity())
+}
+
+func TestTween_VelocityRetargeted(t *testing.T) {
+	tween := New(0, 10, 10, ease.OutQuad)
+	tween.Update(3)
+	velocity := tween.Velocity()
+	tween.Retarget(-10, 4)
+	assert.InDelta(t, velocity, tween.Velocity(), 1e-4)
+	_, after := velocityAround(tween, 0.001)
+	assert.InDelta(t, tween.Velocity(), after, 0.05)
+}
+
+func TestTween_TimeAt(t *testing.T) {
+	tween := New(10, 20, 10, ease.InQuad)
+	assert.Equal(t, []float32{5}, tween.TimeAt(12.5))
+	assert.Empty(t, tween.TimeAt(30))
+
+	tween = New(0, 10, 10, ease.OutBack)
+	times := tween.TimeAt(10.5)
+	assert.Equal(t, 2, len(times))
+	for _, time := range times {
+		current, _ := tween.Set(time)
+		assert.InDelta(t, 10.5, current, 1e-3)
+	}
+}
+
+func TestTween_TimeAtRetargeted(t *testing.T) {
+	tween := New(0, 10, 10, ease.Linear)
+	tween.Update(5)
+	tween.Retarget(0, 10)
+	times := tween.TimeAt(2)
+	assert.Equal(t, 1, len(times))
+	current, _ := tween.Set(times[0])
+	assert.InDelta(t, 2, current, 1e-3)
+}
+
+func TestTween_ZeroDuration(t *testing.T) {
+	tween := New(0, 10, 0, ease.Linear)
+	assert.Equal(t, float32(10), tween.Value())
+	current, isFinished := tween.Update(0.5)
+	assert.Equal(t, float32(10), current)
+	assert.True(t, isFinished)
+	assert.Equal(t, float32(0.5), tween.Overflow)
+	assert.Equal(t, float32(0), tween.Velocity())
+
+	tween.reverse = true
+	current, isFinished = tween.Update(0.5)
+	assert.Equal(t, float32(0), current)
+	assert.True(t, isFinished)
+	assert.Equal(t, float32(-0.5), tween.Overflow)
+}
+
+func TestTween_NegativeDuration(t *testing.T) {
+	tween := New(0, 10, -1, ease.Linear)
+	assert.Equal(t, float32(0), tween.Duration())
+	current, isFinished := tween.Update(0)
+	assert.Equal(t, float32(10), current)
+	assert.True(t, isFinished)
+
+	tween = New(0, 10, 10, ease.Linear)
+	tween.Retarget(20, -5)
+	assert.Equal(t, float32(0), tween.Duration())
+	assert.Equal(t, float32(20), tween.Value())
+}
+
+func TestNewChecked(t *testing.T) {
+	tween, err := NewChecked(0, 10, 0, ease.Linear)
+	assert.Nil(t, err)
+	assert.Equal(t, float32(10), tween.Value())
+	tween, err = NewChecked(0, 10, 2, ease.Linear)
+	assert.Nil(t, err)
+	assert.Equal(t, float32(2), tween.Duration())
+
+	for _, duration := range []float64{-1, math.NaN(), math.Inf(1)} {
+		tween, err = NewChecked(0, 10, float32(duration), ease.Linear)
+		assert.Nil(t, tween)
+		assert.Equal(t, ErrInvalidDuration, err)
+	}
+}
@@ -0,0 +1,62 @@
+package gween
+
+// labels holds the identifying id and tags shared by Tweens and Sequences, which
+// allows them to be found and controlled together within a Group.
+type labels struct {
+	id   string
+	tags []string
+}
+
+// ID returns the id set with SetID, or an empty string if none was set.
+func (l *labels) ID() string {
+	return l.id
+}
+
+// SetID sets an id that can be used to find and control this animation within a
+// Group, for example the name of the entity it animates.
+func (l *labels) SetID(id string) {
+	l.id = id
+}
+
+// Tags returns the tags that have been added to this animation.
+func (l *labels) Tags() []string {
+	return l.tags
+}
+
+// Tag adds one or more tags to this animation. Adding a tag that is already
+// present has no effect.
+func (l *labels) Tag(tags ...string) {
+	for _, tag := range tags {
+		if !l.HasTag(tag) {
+			l.tags = append(l.tags, tag)
+		}
+	}
+}
+
+// Untag removes one or more tags from this animation.
+func (l *labels) Untag(tags ...string) {
+	for _, tag := range tags {
+		for i, existing := range l.tags {
+			if existing == tag {
+				l.tags = append(l.tags[:i], l.tags[i+1:]...)
+				break
+			}
+		}
+	}
+}
+
+// HasTag returns whether this animation has been tagged with tag.
+func (l *labels) HasTag(tag string) bool {
+	for _, existing := range l.tags {
+		if existing == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// matches returns whether the selector refers to this animation, either by id or
+// by tag.
+func (l *labels) matches(selector string) bool {
+	return selector != "" && (l.id == selector || l.HasTag(selector))
+}
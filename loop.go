@@ -0,0 +1,321 @@
+package gween
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/tanema/gween/ease"
+)
+
+type (
+	// LoopMode describes how a looping Tween or Sequence plays each loop after
+	// the first.
+	LoopMode int
+
+	// YoyoLoops describes how a yoyo Sequence counts its loops. The zero value
+	// counts a trip out to the end and back to the beginning as one loop, which is
+	// consumed on arriving back at the beginning, even if SetReverse turned the
+	// Sequence around before it reached the end.
+	YoyoLoops struct {
+		// HalfCycles counts the trip out to the end and the trip back to the
+		// beginning as a loop each, so a Sequence playing an odd number of loops
+		// finishes at the end.
+		HalfCycles bool
+		// IgnoreReversals stops arriving at an end from consuming a loop if
+		// SetReverse turned the Sequence around since it left the other end. The
+		// Sequence turns around and carries on with the same loop instead.
+		IgnoreReversals bool
+	}
+)
+
+const (
+	// LoopRestart jumps back to the beginning at the end of every loop.
+	LoopRestart LoopMode = iota
+	// LoopYoyo plays forward and then backward, a loop being a trip there and
+	// back. Played backward, easing functions are mirrored in time, so an OutQuad
+	// Tween eases in on the way back.
+	LoopYoyo
+	// LoopIncremental continues every loop from where the previous one ended,
+	// moving the values on by the change of a loop each time, for example to step
+	// a counter.
+	LoopIncremental
+	// LoopPingPong plays forward and then backward like LoopYoyo, but on the way
+	// back plays the back easing forward in time, so an OutQuad Tween eases out in
+	// both directions unless a different back easing is set.
+	LoopPingPong
+	// LoopShuffle plays the Tweens of a Sequence in a new random order every loop
+	// after the first, by reordering its Tweens. Snapshots capture the order, but
+	// not the state of the source of randomness, so a restored Sequence shuffles
+	// later loops with its own source. It is the same as LoopRestart for a single
+	// Tween.
+	LoopShuffle
+)
+
+var loopModeNames = map[LoopMode]string{
+	LoopRestart:     "restart",
+	LoopYoyo:        "yoyo",
+	LoopIncremental: "incremental",
+	LoopPingPong:    "pingpong",
+	LoopShuffle:     "shuffle",
+}
+
+func (mode LoopMode) String() string {
+	if name, ok := loopModeNames[mode]; ok {
+		return name
+	}
+	return fmt.Sprintf("LoopMode(%d)", int(mode))
+}
+
+// bounces returns whether the mode plays forward and then backward.
+func (mode LoopMode) bounces() bool {
+	return mode == LoopYoyo || mode == LoopPingPong
+}
+
+// SetLoop sets the number of times the Tween plays, and the number of loops it
+// has remaining, -1 to play forever. Update only reports a looping Tween as
+// finished once its last loop finishes. Defaults to 1.
+func (tween *Tween) SetLoop(amount int) {
+	tween.loop = amount
+	tween.loopRemaining = amount
+	tween.completedLoops = 0
+}
+
+// Loop returns the number of times the Tween was set to play with SetLoop.
+func (tween *Tween) Loop() int {
+	return tween.loop
+}
+
+// LoopsRemaining returns the number of loops the Tween has left to play,
+// including the current one, 0 once it has finished or -1 if it loops forever.
+// When an Update stops exactly at the end of a loop with more to play, the Tween
+// moves on to the next loop straight away. A Sequence only does so on the next
+// Update, so the same elapsed time can count one loop fewer played on a Tween
+// than on a Sequence.
+func (tween *Tween) LoopsRemaining() int {
+	if tween.loopRemaining > 0 && tween.finished() && !tween.looping() {
+		return 0
+	}
+	return tween.loopRemaining
+}
+
+// CurrentLoop returns the zero-based index of the loop the Tween is playing, or
+// of its last loop once it has finished. Loops are counted as described by
+// LoopsRemaining.
+func (tween *Tween) CurrentLoop() int {
+	return tween.completedLoops
+}
+
+// SetLoopMode sets how the Tween plays each loop after the first. Yoyo and
+// ping-pong Tweens play there and back in each loop, so they return to where
+// they started even when they only play once. Defaults to LoopRestart.
+func (tween *Tween) SetLoopMode(mode LoopMode) {
+	tween.loopMode = mode
+	tween.mirrored = tween.returning && mode == LoopPingPong
+}
+
+// LoopMode returns how the Tween plays each loop after the first.
+func (tween *Tween) LoopMode() LoopMode {
+	return tween.loopMode
+}
+
+// SetBackEasing sets the easing function used on the way back of a ping-pong,
+// either of the Tween itself or of a Sequence it is in. Defaults to the easing
+// function of the Tween.
+func (tween *Tween) SetBackEasing(easing ease.TweenFunc) {
+	tween.backEasing = easing
+}
+
+// BackEasing returns the easing function set with SetBackEasing.
+func (tween *Tween) BackEasing() ease.TweenFunc {
+	return tween.backEasing
+}
+
+// looping returns whether a finished Tween has another loop to play. Instant
+// Tweens never loop, as they would loop forever without consuming any time.
+func (tween *Tween) looping() bool {
+	if tween.duration <= 0 {
+		return false
+	}
+	if tween.loopMode.bounces() && !tween.returning {
+		return true
+	}
+	return tween.loopRemaining > 1 || tween.loopRemaining < 0
+}
+
+// nextLoop starts the next loop of a finished Tween, carrying its overflow into
+// it.
+func (tween *Tween) nextLoop() (current float32, isFinished bool) {
+	overflow := tween.Overflow
+	if overflow < 0 {
+		overflow *= -1
+	}
+	switch tween.loopMode {
+	case LoopYoyo, LoopPingPong:
+		// A loop is a trip there and back
+		if tween.returning {
+			tween.countLoop()
+		}
+		tween.bounce()
+	case LoopIncremental:
+		tween.countLoop()
+		tween.increment(1)
+		tween.restart()
+	default:
+		tween.countLoop()
+		tween.restart()
+	}
+	if tween.reverse {
+		return tween.Set(tween.time - overflow)
+	}
+	return tween.Set(tween.time + overflow)
+}
+
+// countLoop consumes a loop, unless the Tween loops forever.
+func (tween *Tween) countLoop() {
+	if tween.loopRemaining > 0 {
+		tween.loopRemaining--
+	}
+	tween.completedLoops++
+}
+
+// bounce turns the Tween around, on its way back or out again.
+func (tween *Tween) bounce() {
+	tween.returning = !tween.returning
+	tween.reverse = !tween.reverse
+	tween.mirrored = tween.returning && tween.loopMode == LoopPingPong
+}
+
+// increment moves the begin and end values of the Tween on by the change of
+// loops loops in the direction it is running.
+func (tween *Tween) increment(loops float32) {
+	delta := tween.change * loops
+	if tween.reverse {
+		delta *= -1
+	}
+	tween.begin += delta
+	tween.end += delta
+	tween.shift += delta
+}
+
+// restart jumps to the beginning of the current loop without changing Overflow.
+func (tween *Tween) restart() {
+	if tween.reverse {
+		tween.time = tween.duration
+	} else {
+		tween.time = 0
+	}
+}
+
+// back returns the easing function used on the way back of a ping-pong.
+func (tween *Tween) back() ease.TweenFunc {
+	if tween.backEasing != nil {
+		return tween.backEasing
+	}
+	return tween.easing
+}
+
+// SetLoopMode sets how the Sequence plays each loop after the first. LoopYoyo is
+// the same as SetYoyo(true). Defaults to LoopRestart.
+func (seq *Sequence) SetLoopMode(mode LoopMode) {
+	seq.loopMode = mode
+	seq.yoyo = mode.bounces()
+}
+
+// LoopMode returns how the Sequence plays each loop after the first.
+func (seq *Sequence) LoopMode() LoopMode {
+	return seq.loopMode
+}
+
+// SetYoyoLoops sets how a yoyo Sequence counts its loops.
+func (seq *Sequence) SetYoyoLoops(loops YoyoLoops) {
+	seq.yoyoLoops = loops
+}
+
+// YoyoLoops returns how a yoyo Sequence counts its loops.
+func (seq *Sequence) YoyoLoops() YoyoLoops {
+	return seq.yoyoLoops
+}
+
+// LoopsRemaining returns the number of loops the Sequence has left to play,
+// including the current one, 0 once it has finished or -1 if it loops forever.
+// When an Update stops exactly at the end of a loop with more to play, the loop
+// is only consumed by the next Update, as it moves on to the next loop. A Tween
+// moves on straight away, so the same elapsed time can count one loop more
+// played on a Tween than on a Sequence.
+func (seq *Sequence) LoopsRemaining() int {
+	return seq.loopRemaining
+}
+
+// CurrentLoop returns the zero-based index of the loop the Sequence is playing,
+// or of its last loop once it has finished. Loops are counted as described by
+// LoopsRemaining.
+func (seq *Sequence) CurrentLoop() int {
+	return seq.completedLoops
+}
+
+// SetShuffleRand sets the source of randomness used to shuffle the Tweens of a
+// LoopShuffle Sequence, so that the order can be reproduced. Defaults to the
+// global source of the math/rand package.
+func (seq *Sequence) SetShuffleRand(r *rand.Rand) {
+	seq.rand = r
+}
+
+// shuffle puts the Tweens in a new random order, to be resolved again against
+// their new neighbours.
+func (seq *Sequence) shuffle() {
+	if len(seq.order) != len(seq.Tweens) {
+		seq.order = make([]int, len(seq.Tweens))
+		for i := range seq.order {
+			seq.order[i] = i
+		}
+	}
+	swap := func(i, j int) {
+		seq.Tweens[i], seq.Tweens[j] = seq.Tweens[j], seq.Tweens[i]
+		seq.order[i], seq.order[j] = seq.order[j], seq.order[i]
+	}
+	if seq.rand != nil {
+		seq.rand.Shuffle(len(seq.Tweens), swap)
+	} else {
+		rand.Shuffle(len(seq.Tweens), swap)
+	}
+	for _, tween := range seq.Tweens {
+		if tween.kind != KindAbsolute {
+			tween.resolved = false
+		}
+	}
+}
+
+// countsLoop returns whether arriving at an end consumes a loop, which it always
+// does once the Sequence has finished.
+func (seq *Sequence) countsLoop() bool {
+	return seq.loopRemaining == 0 || !(seq.yoyoLoops.IgnoreReversals && seq.turned)
+}
+
+// waiting returns whether the Sequence stopped exactly at the end of a loop with
+// more to play, in which case the loop is consumed once the next one starts.
+func (seq *Sequence) waiting(remaining float32) bool {
+	return remaining == 0 && seq.loopRemaining != 0 && seq.loopRemaining != 1
+}
+
+// consumeLoop consumes a loop, unless the Sequence loops forever, and returns
+// whether the Sequence has finished.
+func (seq *Sequence) consumeLoop() bool {
+	if seq.loopRemaining >= 1 {
+		seq.loopRemaining--
+	}
+	if seq.loopRemaining == 0 {
+		return true
+	}
+	seq.completedLoops++
+	return false
+}
+
+// increment moves the values of the Sequence on by the change of loops loops in
+// the direction it is running.
+func (seq *Sequence) increment(loops int) {
+	delta := (seq.Tweens[len(seq.Tweens)-1].end - seq.Tweens[0].begin) * float32(loops)
+	if seq.reverse {
+		delta *= -1
+	}
+	seq.shift += delta
+}
@@ -0,0 +1,564 @@
+package gween
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tanema/gween/ease"
+)
+
+func TestLoopMode_String(t *testing.T) {
+	assert.Equal(t, "pingpong", LoopPingPong.String())
+	assert.Equal(t, "LoopMode(9)", LoopMode(9).String())
+}
+
+func TestTween_LoopRestart(t *testing.T) {
+	tween := New(0, 10, 1, ease.Linear)
+	tween.SetLoop(3)
+	current, isFinished := tween.Update(1.5)
+	assert.Equal(t, float32(5), current)
+	assert.False(t, isFinished)
+	current, isFinished = tween.Update(1.5)
+	assert.Equal(t, float32(10), current)
+	assert.True(t, isFinished)
+
+	tween.Reset()
+	tween.SetLoop(-1)
+	current, isFinished = tween.Update(100.25)
+	assert.Equal(t, float32(2.5), current)
+	assert.False(t, isFinished)
+}
+
+func TestTween_LoopYoyo(t *testing.T) {
+	tween := New(0, 10, 1, ease.OutQuad)
+	tween.SetLoopMode(LoopYoyo)
+	current, isFinished := tween.Update(1.5)
+	assert.Equal(t, ease.OutQuad(0.5, 0, 10, 1), current)
+	assert.False(t, isFinished)
+	current, isFinished = tween.Update(0.5)
+	assert.Equal(t, float32(0), current)
+	assert.True(t, isFinished)
+
+	// Reset turns it back around
+	tween.Reset()
+	current, _ = tween.Update(0.5)
+	assert.Equal(t, ease.OutQuad(0.5, 0, 10, 1), current)
+}
+
+func TestTween_LoopPingPong(t *testing.T) {
+	tween := New(0, 10, 1, ease.OutQuad)
+	tween.SetLoopMode(LoopPingPong)
+	tween.SetLoop(2)
+	current, _ := tween.Update(1.25)
+	// Eases out on the way back too
+	assert.Equal(t, ease.OutQuad(0.25, 10, -10, 1), current)
+	assert.True(t, tween.Velocity() < 0)
+
+	tween.SetBackEasing(ease.InQuad)
+	assert.Equal(t, ease.InQuad(0.25, 10, -10, 1), tween.Value())
+	// Running in reverse, 0.25 into the way back is at 0.75 into the Tween
+	assert.Equal(t, []float32{0.75}, tween.TimeAt(ease.InQuad(0.25, 10, -10, 1)))
+
+	// The second loop eases out again on the way there
+	current, isFinished := tween.Update(1)
+	assert.Equal(t, ease.OutQuad(0.25, 0, 10, 1), current)
+	assert.False(t, isFinished)
+	current, isFinished = tween.Update(1.75)
+	assert.Equal(t, float32(0), current)
+	assert.True(t, isFinished)
+}
+
+func TestTween_LoopIncremental(t *testing.T) {
+	tween := New(0, 10, 1, ease.Linear)
+	tween.SetLoopMode(LoopIncremental)
+	tween.SetLoop(3)
+	current, isFinished := tween.Update(2.5)
+	assert.Equal(t, float32(25), current)
+	assert.False(t, isFinished)
+	current, isFinished = tween.Update(0.5)
+	assert.Equal(t, float32(30), current)
+	assert.True(t, isFinished)
+
+	tween.Reset()
+	assert.Equal(t, float32(0), tween.Value())
+	assert.Equal(t, float32(10), tween.End())
+
+	tween.Complete()
+	assert.Equal(t, float32(30), tween.Value())
+}
+
+func TestTween_LoopInstant(t *testing.T) {
+	tween := New(0, 10, 0, ease.Linear)
+	tween.SetLoop(-1)
+	current, isFinished := tween.Update(1)
+	assert.Equal(t, float32(10), current)
+	assert.True(t, isFinished)
+}
+
+func TestTween_LoopInSequence(t *testing.T) {
+	looping := New(1, 2, 1, ease.Linear)
+	looping.SetLoop(2)
+	seq := NewSequence(New(0, 1, 1, ease.Linear), looping, New(2, 3, 1, ease.Linear))
+	current, _, _ := seq.Update(2.5)
+	assert.Equal(t, float32(1.5), current)
+	current, _, _ = seq.Update(1)
+	assert.Equal(t, float32(2.5), current)
+}
+
+func TestSequence_LoopIncremental(t *testing.T) {
+	seq := NewSequence(New(0, 1, 1, ease.Linear), New(1, 2, 1, ease.Linear))
+	seq.SetLoopMode(LoopIncremental)
+	seq.SetLoop(3)
+	current, _, _ := seq.Update(2.5)
+	assert.Equal(t, float32(2.5), current)
+	current, _, sequenceComplete := seq.Update(3.5)
+	assert.Equal(t, float32(6), current)
+	assert.True(t, sequenceComplete)
+	assert.Equal(t, float32(6), seq.Value())
+
+	seq.Reset()
+	assert.Equal(t, float32(0), seq.Value())
+	seq.Complete()
+	assert.Equal(t, float32(6), seq.Value())
+}
+
+func TestSequence_LoopPingPong(t *testing.T) {
+	tween := New(0, 10, 1, ease.OutQuad)
+	seq := NewSequence(tween)
+	seq.SetLoopMode(LoopPingPong)
+	assert.True(t, seq.Yoyo())
+	current, _, _ := seq.Update(1.5)
+	assert.Equal(t, ease.OutQuad(0.5, 10, -10, 1), current)
+
+	tween.SetBackEasing(ease.Linear)
+	assert.Equal(t, float32(5), seq.Value())
+
+	seq.SetYoyo(true)
+	assert.Equal(t, LoopPingPong, seq.LoopMode())
+	seq.SetYoyo(false)
+	assert.Equal(t, LoopRestart, seq.LoopMode())
+}
+
+func TestSequence_LoopShuffle(t *testing.T) {
+	newShuffled := func() *Sequence {
+		seq := NewSequence(New(0, 1, 1, ease.Linear), New(1, 2, 1, ease.Linear), New(2, 3, 1, ease.Linear), New(3, 4, 1, ease.Linear))
+		seq.SetLoopMode(LoopShuffle)
+		seq.SetLoop(-1)
+		seq.SetShuffleRand(rand.New(rand.NewSource(1)))
+		return seq
+	}
+	seq := newShuffled()
+	tweens := append([]*Tween(nil), seq.Tweens...)
+	// The first loop plays in order
+	for i := 0; i < 4; i++ {
+		current, _, _ := seq.Update(0.5)
+		assert.Equal(t, float32(i)+0.5, current)
+		seq.Update(0.5)
+	}
+	seq.Update(0.5)
+	assert.ElementsMatch(t, tweens, seq.Tweens)
+	assert.NotEqual(t, tweens, seq.Tweens)
+
+	// The same source shuffles in the same order
+	again := newShuffled()
+	again.Update(4.5)
+	assert.Equal(t, seq.Value(), again.Value())
+	for i := range seq.Tweens {
+		assert.Equal(t, seq.Tweens[i].Begin(), again.Tweens[i].Begin())
+	}
+}
+
+func TestSequence_LoopShuffleRelative(t *testing.T) {
+	seq := NewSequence(NewBy(1, 1, ease.Linear), NewBy(2, 1, ease.Linear), NewBy(3, 1, ease.Linear))
+	seq.SetLoopMode(LoopShuffle)
+	seq.SetLoop(2)
+	seq.SetShuffleRand(rand.New(rand.NewSource(3)))
+	seq.Update(3.5)
+	// Relative tweens are resolved against their new neighbours
+	for i := 1; i < len(seq.Tweens); i++ {
+		seq.Update(1)
+		assert.Equal(t, seq.Tweens[i-1].End(), seq.Tweens[i].Begin())
+	}
+}
+
+func TestLoop_Snapshot(t *testing.T) {
+	tween := New(0, 10, 1, ease.OutQuad)
+	tween.SetLoopMode(LoopPingPong)
+	tween.SetLoop(3)
+	tween.Update(1.25)
+	state := tween.Snapshot()
+	expected, _ := tween.Update(0.5)
+
+	data, err := state.MarshalBinary()
+	require.NoError(t, err)
+	var decoded TweenState
+	require.NoError(t, decoded.UnmarshalBinary(data))
+	assert.Equal(t, state, decoded)
+
+	restored := New(0, 10, 1, ease.OutQuad)
+	restored.Restore(decoded)
+	current, _ := restored.Update(0.5)
+	assert.Equal(t, expected, current)
+
+	seq := NewSequence(New(0, 1, 1, ease.Linear), New(1, 2, 1, ease.Linear))
+	seq.SetLoopMode(LoopIncremental)
+	seq.SetLoop(3)
+	seq.Update(2.5)
+	seqState := seq.Snapshot()
+	data, err = seqState.MarshalBinary()
+	require.NoError(t, err)
+	var decodedSeq SequenceState
+	require.NoError(t, decodedSeq.UnmarshalBinary(data))
+	assert.Equal(t, seqState, decodedSeq)
+	seq.Reset()
+	require.NoError(t, seq.Restore(decodedSeq))
+	assert.Equal(t, float32(2.5), seq.Value())
+}
+
+func TestSequence_LoopBoundary(t *testing.T) {
+	seq := NewSequence(New(0, 1, 1, ease.Linear), New(1, 2, 1, ease.Linear))
+	seq.SetLoop(2)
+	current, _, isFinished := seq.Update(2)
+	assert.Equal(t, float32(2), current)
+	assert.False(t, isFinished)
+	assert.Equal(t, 2, seq.LoopsRemaining())
+	assert.Equal(t, 0, seq.CurrentLoop())
+	current, _, isFinished = seq.Update(0.5)
+	assert.Equal(t, float32(0.5), current)
+	assert.False(t, isFinished)
+	assert.Equal(t, 1, seq.LoopsRemaining())
+	assert.Equal(t, 1, seq.CurrentLoop())
+	current, _, isFinished = seq.Update(1.5)
+	assert.Equal(t, float32(2), current)
+	assert.True(t, isFinished)
+	assert.Equal(t, 0, seq.LoopsRemaining())
+	assert.Equal(t, 1, seq.CurrentLoop())
+
+	// Finished Sequences stay finished
+	current, _, isFinished = seq.Update(1)
+	assert.Equal(t, float32(2), current)
+	assert.True(t, isFinished)
+}
+
+func TestSequence_YoyoHalfCycles(t *testing.T) {
+	seq := NewSequence(New(0, 1, 1, ease.Linear), New(1, 2, 1, ease.Linear))
+	seq.SetYoyo(true)
+	seq.SetYoyoLoops(YoyoLoops{HalfCycles: true})
+	seq.SetLoop(3)
+	current, _, isFinished := seq.Update(2.5)
+	assert.Equal(t, float32(1.5), current)
+	assert.False(t, isFinished)
+	assert.Equal(t, 2, seq.LoopsRemaining())
+	assert.Equal(t, 1, seq.CurrentLoop())
+	current, _, isFinished = seq.Update(3.5)
+	assert.Equal(t, float32(2), current)
+	assert.True(t, isFinished)
+	assert.Equal(t, 0, seq.LoopsRemaining())
+	assert.Equal(t, 2, seq.CurrentLoop())
+}
+
+func TestSequence_YoyoIgnoreReversals(t *testing.T) {
+	seq := NewSequence(New(0, 1, 1, ease.Linear), New(1, 2, 1, ease.Linear))
+	seq.SetYoyo(true)
+	seq.SetYoyoLoops(YoyoLoops{IgnoreReversals: true})
+	seq.SetLoop(2)
+	seq.Update(1.5)
+	seq.SetReverse(true)
+
+	// Turns around at the beginning without consuming a loop
+	current, _, isFinished := seq.Update(2)
+	assert.Equal(t, float32(0.5), current)
+	assert.False(t, isFinished)
+	assert.False(t, seq.Reverse())
+	assert.Equal(t, 2, seq.LoopsRemaining())
+	assert.Equal(t, 0, seq.CurrentLoop())
+
+	// Then counts loops as usual
+	current, _, isFinished = seq.Update(4)
+	assert.Equal(t, float32(0.5), current)
+	assert.False(t, isFinished)
+	assert.Equal(t, 1, seq.LoopsRemaining())
+	assert.Equal(t, 1, seq.CurrentLoop())
+	current, _, isFinished = seq.Update(3.5)
+	assert.Equal(t, float32(0), current)
+	assert.True(t, isFinished)
+}
+
+func TestSequence_LoopAccountingSnapshot(t *testing.T) {
+	seq := NewSequence(New(0, 1, 1, ease.Linear), New(1, 2, 1, ease.Linear))
+	seq.SetYoyo(true)
+	seq.SetYoyoLoops(YoyoLoops{HalfCycles: true, IgnoreReversals: true})
+	seq.SetLoop(4)
+	seq.Update(2.5)
+	seq.SetReverse(false)
+	state := seq.Snapshot()
+	data, err := state.MarshalBinary()
+	require.NoError(t, err)
+	var decoded SequenceState
+	require.NoError(t, decoded.UnmarshalBinary(data))
+	assert.Equal(t, state, decoded)
+	assert.True(t, decoded.Turned)
+	assert.Equal(t, 1, decoded.CompletedLoops)
+
+	restored := NewSequence(New(0, 1, 1, ease.Linear), New(1, 2, 1, ease.Linear))
+	require.NoError(t, restored.Restore(decoded))
+	assert.Equal(t, seq.YoyoLoops(), restored.YoyoLoops())
+	assert.Equal(t, seq.LoopsRemaining(), restored.LoopsRemaining())
+	assert.Equal(t, seq.CurrentLoop(), restored.CurrentLoop())
+	expected, _, _ := seq.Update(3)
+	current, _, _ := restored.Update(3)
+	assert.Equal(t, expected, current)
+	assert.Equal(t, seq.LoopsRemaining(), restored.LoopsRemaining())
+}
+
+// loopCase is a randomly generated looping Sequence of linear Tweens, from 0 to 1,
+// 1 to 2 and so on, along with the steps it is played in. Durations and steps are
+// multiples of powers of two so that float32 arithmetic on them is exact.
+type loopCase struct {
+	Durations []float32
+	Loop      int
+	Yoyo      bool
+	YoyoLoops YoyoLoops
+	Steps     []float32
+}
+
+func (loopCase) Generate(r *rand.Rand, size int) reflect.Value {
+	c := loopCase{
+		Durations: make([]float32, 1+r.Intn(4)),
+		Loop:      1 + r.Intn(4),
+		Yoyo:      r.Intn(2) == 0,
+		YoyoLoops: YoyoLoops{HalfCycles: r.Intn(2) == 0},
+		Steps:     make([]float32, 1+r.Intn(40)),
+	}
+	for i := range c.Durations {
+		c.Durations[i] = float32(1+r.Intn(16)) / 8
+	}
+	for i := range c.Steps {
+		c.Steps[i] = float32(1+r.Intn(64)) / 64
+	}
+	return reflect.ValueOf(c)
+}
+
+func (c loopCase) sequence() *Sequence {
+	seq := NewSequence()
+	for i, duration := range c.Durations {
+		seq.Add(New(float32(i), float32(i+1), duration, ease.Linear))
+	}
+	seq.SetLoop(c.Loop)
+	seq.SetYoyo(c.Yoyo)
+	seq.SetYoyoLoops(c.YoyoLoops)
+	return seq
+}
+
+// duration returns how long a single pass through the Tweens takes.
+func (c loopCase) duration() float32 {
+	var duration float32
+	for _, d := range c.Durations {
+		duration += d
+	}
+	return duration
+}
+
+// period returns how long a single loop takes.
+func (c loopCase) period() float32 {
+	if c.Yoyo && !c.YoyoLoops.HalfCycles {
+		return 2 * c.duration()
+	}
+	return c.duration()
+}
+
+// valueAt returns the value of a single pass through the Tweens at time.
+func (c loopCase) valueAt(time float32) float32 {
+	for i, d := range c.Durations {
+		if time <= d {
+			return float32(i) + time/d
+		}
+		time -= d
+	}
+	return float32(len(c.Durations))
+}
+
+// expected returns the value, current loop and whether the Sequence has finished
+// after being played for time.
+func (c loopCase) expected(time float32) (value float32, loop int, finished bool) {
+	period, duration := c.period(), c.duration()
+	if time >= period*float32(c.Loop) {
+		switch {
+		case !c.Yoyo:
+			return float32(len(c.Durations)), c.Loop - 1, true
+		case c.YoyoLoops.HalfCycles && c.Loop%2 == 1:
+			return float32(len(c.Durations)), c.Loop - 1, true
+		}
+		return 0, c.Loop - 1, true
+	}
+	// Stopping exactly at the end of a loop is still part of that loop
+	loop = int(math.Ceil(float64(time/period))) - 1
+	elapsed := time - float32(loop)*period
+	switch {
+	case !c.Yoyo:
+		return c.valueAt(elapsed), loop, false
+	case c.YoyoLoops.HalfCycles && loop%2 == 1:
+		return c.valueAt(duration - elapsed), loop, false
+	case !c.YoyoLoops.HalfCycles && elapsed > duration:
+		return c.valueAt(2*duration - elapsed), loop, false
+	}
+	return c.valueAt(elapsed), loop, false
+}
+
+var quickConfig = &quick.Config{MaxCount: 300}
+
+func TestSequence_LoopAccounting(t *testing.T) {
+	property := func(c loopCase) bool {
+		seq := c.sequence()
+		var time float32
+		for _, step := range c.Steps {
+			time += step
+			current, _, sequenceComplete := seq.Update(step)
+			value, loop, finished := c.expected(time)
+			if sequenceComplete != finished || seq.CurrentLoop() != loop ||
+				math.Abs(float64(current-value)) > 1e-4 {
+				t.Logf("%+v at %v: got %v loop %v finished %v, want %v loop %v finished %v",
+					c, time, current, seq.CurrentLoop(), sequenceComplete, value, loop, finished)
+				return false
+			}
+			if finished && seq.LoopsRemaining() != 0 || !finished && seq.LoopsRemaining()+seq.CurrentLoop() != c.Loop {
+				t.Logf("%+v at %v: %v loops remaining in loop %v", c, time, seq.LoopsRemaining(), seq.CurrentLoop())
+				return false
+			}
+		}
+		return true
+	}
+	if err := quick.Check(property, quickConfig); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSequence_LoopAccountingSplit(t *testing.T) {
+	// Playing in steps ends up in exactly the same state as playing all at once
+	property := func(c loopCase) bool {
+		stepped, whole := c.sequence(), c.sequence()
+		var time float32
+		for _, step := range c.Steps {
+			time += step
+			stepped.Update(step)
+		}
+		whole.Update(time)
+		// Overflow only records what was left of the step that completed a Tween
+		wholeState, steppedState := whole.Snapshot(), stepped.Snapshot()
+		for i := range wholeState.Tweens {
+			wholeState.Tweens[i].Overflow, steppedState.Tweens[i].Overflow = 0, 0
+		}
+		return assert.Equal(t, wholeState, steppedState, "%+v", c)
+	}
+	if err := quick.Check(property, quickConfig); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSequence_LoopAccountingComplete(t *testing.T) {
+	// Complete ends up where playing every loop does
+	property := func(c loopCase) bool {
+		played, completed := c.sequence(), c.sequence()
+		var time float32
+		for _, step := range c.Steps {
+			time += step
+			played.Update(step)
+			completed.Update(step)
+		}
+		played.Update(c.period() * float32(c.Loop))
+		completed.Complete()
+		return assert.Equal(t, played.Value(), completed.Value(), "%+v", c) &&
+			assert.Equal(t, 0, completed.LoopsRemaining(), "%+v", c) &&
+			assert.Equal(t, played.CurrentLoop(), completed.CurrentLoop(), "%+v", c)
+	}
+	if err := quick.Check(property, quickConfig); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSequence_LoopAccountingReversals(t *testing.T) {
+	// Turning around before reaching the end only consumes a loop on arriving
+	// back at the beginning when reversals are not ignored
+	property := func(c loopCase, ignore bool) bool {
+		c.Yoyo, c.YoyoLoops.IgnoreReversals = true, ignore
+		seq := c.sequence()
+		out := c.duration() * c.Steps[0] / 2
+		seq.Update(out)
+		seq.SetReverse(true)
+		seq.Update(out + c.duration()*c.Steps[len(c.Steps)-1]/2)
+		consumed := c.Loop - seq.LoopsRemaining()
+		switch {
+		case ignore:
+			return assert.Equal(t, 0, consumed, "%+v", c) && assert.False(t, seq.Reverse())
+		case c.Loop == 1:
+			return assert.Equal(t, 1, consumed, "%+v", c)
+		}
+		return assert.Equal(t, 1, consumed, "%+v", c) && assert.Equal(t, 1, seq.CurrentLoop())
+	}
+	if err := quick.Check(property, quickConfig); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestLoop_BoundaryCounting(t *testing.T) {
+	tween := New(0, 1, 1, ease.Linear)
+	tween.SetLoop(3)
+	seq := NewSequence(New(0, 1, 1, ease.Linear))
+	seq.SetLoop(3)
+
+	// Stopped exactly at the end of the first loop, only the Tween has moved on
+	tween.Update(1)
+	seq.Update(1)
+	assert.Equal(t, 2, tween.LoopsRemaining())
+	assert.Equal(t, 1, tween.CurrentLoop())
+	assert.Equal(t, 3, seq.LoopsRemaining())
+	assert.Equal(t, 0, seq.CurrentLoop())
+
+	// Anywhere else they agree
+	tween.Update(0.5)
+	seq.Update(0.5)
+	assert.Equal(t, tween.LoopsRemaining(), seq.LoopsRemaining())
+	assert.Equal(t, tween.CurrentLoop(), seq.CurrentLoop())
+}
+
+func TestTween_LoopAccounting(t *testing.T) {
+	property := func(c loopCase, mode uint8) bool {
+		tween := New(0, 1, c.Durations[0], ease.Linear)
+		tween.SetLoop(c.Loop)
+		tween.SetLoopMode(LoopMode(mode % 4))
+		period := c.Durations[0]
+		if tween.LoopMode().bounces() {
+			period *= 2
+		}
+		var time float32
+		for _, step := range c.Steps {
+			time += step
+			_, isFinished := tween.Update(step)
+			finished := time >= period*float32(c.Loop)
+			// A Tween starts its next loop as soon as it gets to the end of one,
+			// as documented on LoopsRemaining
+			loop := int(math.Floor(float64(time / period)))
+			if finished {
+				loop = c.Loop - 1
+			}
+			if !assert.Equal(t, finished, isFinished, "%+v at %v", c, time) ||
+				!assert.Equal(t, loop, tween.CurrentLoop(), "%+v at %v", c, time) {
+				return false
+			}
+			if finished && tween.LoopsRemaining() != 0 || !finished && tween.LoopsRemaining()+tween.CurrentLoop() != c.Loop {
+				t.Logf("%+v at %v: %v loops remaining in loop %v", c, time, tween.LoopsRemaining(), tween.CurrentLoop())
+				return false
+			}
+		}
+		return true
+	}
+	if err := quick.Check(property, quickConfig); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,128 @@
+package gween
+
+import "sync"
+
+// Manager is a Group that is safe to use from multiple goroutines. Every
+// operation holds a lock, so animations can be added, killed, sought and updated
+// from request handlers while a game loop updates them. Animations added to a
+// Manager must only be accessed through it from then on.
+//
+// The callbacks of Sequences, set with NewCallback, SetOnMarker and
+// SetOnTweenComplete, are queued while Update and Seek hold the lock and called
+// in order once it is released, so they can use the Manager themselves.
+type Manager struct {
+	mu    sync.Mutex
+	group Group
+	// pending are the callbacks queued while holding the lock
+	pending []func()
+}
+
+// NewManager returns a new Manager containing the provided animations.
+func NewManager(animations ...Animation) *Manager {
+	return &Manager{group: Group{Animations: animations}}
+}
+
+// Add adds one or more animations to the Manager.
+func (manager *Manager) Add(animations ...Animation) {
+	manager.lock()
+	defer manager.unlock()
+	manager.group.Add(animations...)
+}
+
+// Len returns the number of animations in the Manager.
+func (manager *Manager) Len() int {
+	manager.lock()
+	defer manager.unlock()
+	return manager.group.Len()
+}
+
+// Update advances every animation by dt, removing those that complete. It returns
+// true once the Manager is empty.
+func (manager *Manager) Update(dt float32) (isFinished bool) {
+	manager.lock()
+	defer manager.unlock()
+	return manager.group.Update(dt)
+}
+
+// SetAutoRelease sets whether animations that complete during Update are released
+// back to the pool once they are removed.
+func (manager *Manager) SetAutoRelease(autoRelease bool) {
+	manager.Do(func(group *Group) { group.SetAutoRelease(autoRelease) })
+}
+
+// Value returns the current value of the first animation matching selector, and
+// whether one was found.
+func (manager *Manager) Value(selector string) (float32, bool) {
+	manager.lock()
+	defer manager.unlock()
+	found := manager.group.Find(selector)
+	if len(found) == 0 {
+		return 0, false
+	}
+	return found[0].Value(), true
+}
+
+// Pause pauses every animation matching selector.
+func (manager *Manager) Pause(selector string) {
+	manager.Do(func(group *Group) { group.Pause(selector) })
+}
+
+// Resume resumes every animation matching selector.
+func (manager *Manager) Resume(selector string) {
+	manager.Do(func(group *Group) { group.Resume(selector) })
+}
+
+// Complete jumps every animation matching selector to its final state.
+func (manager *Manager) Complete(selector string) {
+	manager.Do(func(group *Group) { group.Complete(selector) })
+}
+
+// Seek moves every animation matching selector to time, measured from its
+// beginning.
+func (manager *Manager) Seek(selector string, time float32) {
+	manager.Do(func(group *Group) { group.Seek(selector, time) })
+}
+
+// SetTimeScale sets the time scale of every animation matching selector.
+func (manager *Manager) SetTimeScale(selector string, scale float32) {
+	manager.Do(func(group *Group) { group.SetTimeScale(selector, scale) })
+}
+
+// Kill immediately removes every animation matching selector.
+func (manager *Manager) Kill(selector string) {
+	manager.Do(func(group *Group) { group.Kill(selector) })
+}
+
+// Do calls fn with the underlying Group while holding the lock, for operations
+// that need several steps to happen atomically. The Group must not be retained
+// after fn returns. Callbacks are only queued by the Update and Seek methods of
+// the Group, so animations updated directly by fn call theirs while the lock is
+// held, when they must not use the Manager.
+func (manager *Manager) Do(fn func(group *Group)) {
+	manager.lock()
+	defer manager.unlock()
+	fn(&manager.group)
+}
+
+// lock acquires the lock, making sure that callbacks are queued while it is held.
+func (manager *Manager) lock() {
+	manager.mu.Lock()
+	if manager.group.dispatch == nil {
+		manager.group.dispatch = manager.queue
+	}
+}
+
+// unlock releases the lock and then calls the callbacks queued while it was held.
+func (manager *Manager) unlock() {
+	callbacks := manager.pending
+	manager.pending = nil
+	manager.mu.Unlock()
+	for _, fn := range callbacks {
+		fn()
+	}
+}
+
+// queue queues fn to be called once the lock is released.
+func (manager *Manager) queue(fn func()) {
+	manager.pending = append(manager.pending, fn)
+}
@@ -0,0 +1,137 @@
+package gween
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tanema/gween/ease"
+)
+
+func TestManager(t *testing.T) {
+	tween := New(0, 10, 10, ease.Linear)
+	tween.SetID("player")
+	seq := NewSequence(New(0, 1, 1, ease.Linear), New(1, 2, 1, ease.Linear))
+	seq.Tag("ui")
+	manager := NewManager(tween, seq)
+
+	manager.Update(1)
+	value, ok := manager.Value("player")
+	assert.True(t, ok)
+	assert.Equal(t, float32(1), value)
+
+	manager.Seek("player", 5)
+	value, _ = manager.Value("player")
+	assert.Equal(t, float32(5), value)
+
+	manager.Pause("ui")
+	manager.Update(0.5)
+	value, _ = manager.Value("ui")
+	assert.Equal(t, float32(1), value)
+	manager.Resume("ui")
+	manager.SetTimeScale("ui", 2)
+	manager.Update(0.25)
+	value, _ = manager.Value("ui")
+	assert.Equal(t, float32(1.5), value)
+
+	manager.Complete("ui")
+	manager.Update(0)
+	assert.Equal(t, 1, manager.Len())
+	manager.Kill("player")
+	assert.Equal(t, 0, manager.Len())
+	_, ok = manager.Value("player")
+	assert.False(t, ok)
+}
+
+func TestManager_Callbacks(t *testing.T) {
+	manager := NewManager()
+	var calls []string
+	seq := NewSequence(New(0, 1, 1, ease.Linear))
+	seq.Add(NewCallback(func() {
+		calls = append(calls, "callback")
+		manager.Kill("foo")
+	}))
+	seq.Add(New(1, 2, 1, ease.Linear), New(2, 3, 1, ease.Linear))
+	seq.AddMarker("middle", 1.5)
+	seq.SetOnMarker(func(marker Marker, direction Direction) {
+		value, _ := manager.Value("seq")
+		calls = append(calls, fmt.Sprintf("%s %v", marker.Name, value))
+	})
+	seq.SetOnTweenComplete(func(index int) {
+		calls = append(calls, fmt.Sprint(index))
+		manager.Len()
+	})
+	seq.SetID("seq")
+	foo := New(0, 1, 10, ease.Linear)
+	foo.SetID("foo")
+	manager.Add(seq, foo)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		manager.Update(2)
+		manager.Seek("seq", 0.5)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("callbacks deadlocked the Manager")
+	}
+	// Callbacks are called after the Update, so they all see its outcome
+	assert.Equal(t, []string{"0", "callback", "1", "middle 2", "2"}, calls)
+	assert.Equal(t, 1, manager.Len())
+}
+
+// TestManager_Concurrent is meant to be run with -race
+func TestManager_Concurrent(t *testing.T) {
+	manager := NewManager()
+	var updater, workers sync.WaitGroup
+	done := make(chan struct{})
+
+	updater.Add(1)
+	go func() {
+		defer updater.Done()
+		for {
+			select {
+			case <-done:
+				return
+			default:
+				manager.Update(0.01)
+			}
+		}
+	}()
+
+	for worker := 0; worker < 4; worker++ {
+		workers.Add(1)
+		go func(worker int) {
+			defer workers.Done()
+			for i := 0; i < 200; i++ {
+				id := fmt.Sprintf("%d-%d", worker, i%10)
+				tween := New(0, 1, 1, ease.Linear)
+				tween.SetID(id)
+				tween.Tag("worker")
+				manager.Add(tween)
+				manager.Seek(id, 0.5)
+				manager.SetTimeScale("worker", 0.5)
+				manager.Value(id)
+				manager.Pause(id)
+				manager.Resume(id)
+				if i%3 == 0 {
+					manager.Kill(id)
+				}
+				manager.Do(func(group *Group) {
+					group.Find("worker")
+				})
+			}
+		}(worker)
+	}
+
+	workers.Wait()
+	close(done)
+	updater.Wait()
+	manager.Complete("worker")
+	assert.True(t, manager.Update(0))
+}
@@ -0,0 +1,110 @@
+package gween
+
+import "sort"
+
+type (
+	// Direction is the direction a Sequence is playing in.
+	Direction int
+
+	// Marker is a named point in time along a Sequence, used to fire cues such as
+	// sounds or particles part way through a Tween. Time is measured along the
+	// Sequence, where each Tween takes up its duration, so a marker at 1.5 in a
+	// Sequence of two Tweens lasting 1 each is half way through the second Tween.
+	Marker struct {
+		Name string
+		Time float32
+	}
+)
+
+const (
+	// Forward is the direction of a Sequence playing from its first Tween to its last.
+	Forward Direction = 1
+	// Backward is the direction of a Sequence playing from its last Tween to its first.
+	Backward Direction = -1
+)
+
+func (direction Direction) String() string {
+	if direction == Backward {
+		return "backward"
+	}
+	return "forward"
+}
+
+// AddMarker adds a marker named name at time along the Sequence. Several markers
+// can share the same name or time.
+func (seq *Sequence) AddMarker(name string, time float32) {
+	i := sort.Search(len(seq.markers), func(i int) bool { return seq.markers[i].Time > time })
+	seq.markers = append(seq.markers, Marker{})
+	copy(seq.markers[i+1:], seq.markers[i:])
+	seq.markers[i] = Marker{Name: name, Time: time}
+}
+
+// RemoveMarker removes every marker named name from the Sequence.
+func (seq *Sequence) RemoveMarker(name string) {
+	remaining := seq.markers[:0]
+	for _, marker := range seq.markers {
+		if marker.Name != name {
+			remaining = append(remaining, marker)
+		}
+	}
+	seq.markers = remaining
+}
+
+// Markers returns the markers of the Sequence ordered by time.
+func (seq *Sequence) Markers() []Marker {
+	return seq.markers
+}
+
+// SetOnMarker sets a function that is called whenever Update moves the Sequence
+// past a marker, with the direction it was moving in. A marker is passed when
+// the Sequence reaches its time, or leaves it when it is where a loop starts.
+// Every marker passed during a single Update is reported in the order it was
+// passed, including markers passed several times when looping or yoyoing.
+func (seq *Sequence) SetOnMarker(fn func(marker Marker, direction Direction)) {
+	seq.onMarker = fn
+}
+
+// fireMarkers calls the marker callback for every marker passed by the Tween at
+// index moving from time from to time to.
+func (seq *Sequence) fireMarkers(index int, from, to float32) {
+	atStart := seq.atStart
+	seq.atStart = false
+	if seq.onMarker == nil || len(seq.markers) == 0 {
+		return
+	}
+	offset := seq.offset(index)
+	from, to = offset+from, offset+to
+	if seq.reverse {
+		for i := len(seq.markers) - 1; i >= 0; i-- {
+			marker := seq.markers[i]
+			if marker.Time >= to && (marker.Time < from || atStart && marker.Time == from) {
+				seq.markerPassed(marker, Backward)
+			}
+		}
+		return
+	}
+	for _, marker := range seq.markers {
+		if marker.Time <= to && (marker.Time > from || atStart && marker.Time == from) {
+			seq.markerPassed(marker, Forward)
+		}
+	}
+}
+
+// markerPassed calls the marker callback with marker and direction, like call.
+func (seq *Sequence) markerPassed(marker Marker, direction Direction) {
+	if seq.dispatch == nil {
+		seq.onMarker(marker, direction)
+		return
+	}
+	fn := seq.onMarker
+	seq.dispatch(func() { fn(marker, direction) })
+}
+
+// offset returns the time along the Sequence at which the Tween at index starts.
+func (seq *Sequence) offset(index int) float32 {
+	var offset float32
+	for _, tween := range seq.Tweens[:index] {
+		offset += tween.duration
+	}
+	return offset
+}
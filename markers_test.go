@@ -0,0 +1,112 @@
+package gween
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tanema/gween/ease"
+)
+
+// newMarkedSequence returns a Sequence of two Tweens lasting 1 each, with markers
+// at the start, middle and end of each, recording every marker passed.
+func newMarkedSequence() (*Sequence, *[]string) {
+	seq := NewSequence(New(0, 1, 1, ease.Linear), New(1, 2, 1, ease.Linear))
+	seq.AddMarker("end", 2)
+	seq.AddMarker("b", 0.5)
+	seq.AddMarker("start", 0)
+	seq.AddMarker("c", 1)
+	seq.AddMarker("d", 1.5)
+	var passed []string
+	seq.SetOnMarker(func(marker Marker, direction Direction) {
+		passed = append(passed, fmt.Sprintf("%s %v", marker.Name, direction))
+	})
+	return seq, &passed
+}
+
+func TestSequence_AddMarker(t *testing.T) {
+	seq, _ := newMarkedSequence()
+	seq.AddMarker("c2", 1)
+	assert.Equal(t, []Marker{{"start", 0}, {"b", 0.5}, {"c", 1}, {"c2", 1}, {"d", 1.5}, {"end", 2}}, seq.Markers())
+	seq.RemoveMarker("c")
+	seq.RemoveMarker("missing")
+	assert.Equal(t, []Marker{{"start", 0}, {"b", 0.5}, {"c2", 1}, {"d", 1.5}, {"end", 2}}, seq.Markers())
+}
+
+func TestSequence_Markers(t *testing.T) {
+	seq, passed := newMarkedSequence()
+	seq.Update(0.5)
+	assert.Equal(t, []string{"start forward", "b forward"}, *passed)
+	*passed = nil
+	seq.Update(0.5)
+	assert.Equal(t, []string{"c forward"}, *passed)
+	*passed = nil
+	seq.Update(0.25)
+	assert.Empty(t, *passed)
+	seq.Update(0.75)
+	assert.Equal(t, []string{"d forward", "end forward"}, *passed)
+}
+
+func TestSequence_MarkersLargeDt(t *testing.T) {
+	seq, passed := newMarkedSequence()
+	seq.SetLoop(2)
+	_, _, sequenceComplete := seq.Update(5)
+	assert.True(t, sequenceComplete)
+	forward := []string{"start forward", "b forward", "c forward", "d forward", "end forward"}
+	assert.Equal(t, append(forward, forward...), *passed)
+}
+
+func TestSequence_MarkersYoyo(t *testing.T) {
+	seq, passed := newMarkedSequence()
+	seq.SetYoyo(true)
+	seq.SetLoop(2)
+	_, _, sequenceComplete := seq.Update(8)
+	assert.True(t, sequenceComplete)
+	forward := []string{"b forward", "c forward", "d forward", "end forward"}
+	backward := []string{"d backward", "c backward", "b backward", "start backward"}
+	expected := append([]string{"start forward"}, forward...)
+	expected = append(expected, backward...)
+	expected = append(expected, forward...)
+	expected = append(expected, backward...)
+	assert.Equal(t, expected, *passed)
+}
+
+func TestSequence_MarkersReverse(t *testing.T) {
+	seq, passed := newMarkedSequence()
+	seq.SetLoop(2)
+	seq.Update(1.75)
+	*passed = nil
+
+	seq.SetReverse(true)
+	seq.Update(1.5)
+	assert.Equal(t, []string{"d backward", "c backward", "b backward"}, *passed)
+	*passed = nil
+
+	// Wraps around to the end, which is where the reversed loop starts
+	seq.Update(1)
+	assert.Equal(t, []string{"start backward", "end backward", "d backward"}, *passed)
+}
+
+func TestSequence_MarkersSnapshot(t *testing.T) {
+	seq, passed := newMarkedSequence()
+	state := seq.Snapshot()
+	seq.Update(1)
+	*passed = nil
+	assert.Nil(t, seq.Restore(state))
+	seq.Update(1)
+	assert.Equal(t, []string{"start forward", "b forward", "c forward"}, *passed)
+}
+
+func TestSequence_MarkersAllocs(t *testing.T) {
+	seq := newBenchmarkSequence()
+	seq.AddMarker("cue", 0.5)
+	seq.AddMarker("cue", 1.25)
+	count := 0
+	seq.SetOnMarker(func(marker Marker, direction Direction) { count++ })
+	allocs := testing.AllocsPerRun(100, func() {
+		seq.Update(0.7)
+	})
+	assert.Equal(t, float64(0), allocs)
+	assert.True(t, count > 0)
+}
@@ -0,0 +1,182 @@
+package gween
+
+// Reverse returns whether the Tween is heading towards its beginning.
+func (tween *Tween) Reverse() bool {
+	return tween.reverse
+}
+
+// PlayForward makes the Tween head towards its end from wherever it is. A Tween
+// that finished at its beginning plays all of its loops again. Turning a looping
+// Tween around plays the loops it has already played again, except for yoyo and
+// ping-pong Tweens, which turn around as if they had bounced so that arriving
+// back where the current loop started finishes it.
+func (tween *Tween) PlayForward() {
+	tween.head(false)
+}
+
+// PlayBackward makes the Tween head towards its beginning from wherever it is,
+// like PlayForward.
+func (tween *Tween) PlayBackward() {
+	tween.head(true)
+}
+
+// Toggle makes the Tween head the other way, like PlayForward or PlayBackward. A
+// finished Tween heads away from where it finished.
+func (tween *Tween) Toggle() {
+	tween.head(!tween.backward())
+}
+
+// Rewind jumps the Tween back to where playing in the direction it is heading
+// starts, with all of its loops left to play. It is the opposite of Complete.
+func (tween *Tween) Rewind() {
+	tween.rewind(tween.reverse)
+	if tween.reverse && tween.loopMode == LoopIncremental && tween.loop > 1 {
+		// Playing backward starts from where the last loop ends
+		tween.increment(float32(1 - tween.loop))
+	}
+}
+
+// head makes the Tween head towards its beginning, or its end.
+func (tween *Tween) head(reverse bool) {
+	tween.Overflow = 0
+	switch {
+	case tween.duration <= 0:
+		tween.reverse = reverse
+	case tween.done():
+		if reverse == (tween.time <= 0) {
+			// Already where it is heading
+			return
+		}
+		if tween.loopMode.bounces() {
+			tween.rewind(reverse)
+			return
+		}
+		tween.loopRemaining = tween.loop
+		tween.completedLoops = 0
+		tween.reverse = reverse
+	case reverse != tween.reverse:
+		if tween.loopMode.bounces() {
+			// Keeps the easing it is playing so that the value does not jump
+			tween.returning = !tween.returning
+		} else if tween.loop > 0 {
+			tween.loopRemaining = tween.completedLoops + 1
+			tween.completedLoops = tween.loop - tween.loopRemaining
+		}
+		tween.reverse = reverse
+	}
+}
+
+// backward returns whether the Tween is heading towards its beginning, which a
+// finished Tween is if it finished there.
+func (tween *Tween) backward() bool {
+	if tween.duration > 0 && tween.done() {
+		return tween.time <= 0
+	}
+	return tween.reverse
+}
+
+// done returns whether the Tween has finished its last loop.
+func (tween *Tween) done() bool {
+	return tween.finished() && !tween.looping()
+}
+
+// PlayForward makes the Sequence head towards the end of its last Tween from
+// wherever it is, even when it is between loops. A Sequence that finished at its
+// beginning plays all of its loops again. Turning a Sequence that does not yoyo
+// around plays the loops it has already played again, while a yoyo Sequence
+// counts its loops as described by SetYoyoLoops.
+func (seq *Sequence) PlayForward() {
+	seq.head(false)
+}
+
+// PlayBackward makes the Sequence head towards the beginning of its first Tween
+// from wherever it is, like PlayForward.
+func (seq *Sequence) PlayBackward() {
+	seq.head(true)
+}
+
+// Toggle makes the Sequence head the other way, like PlayForward or PlayBackward.
+// A finished Sequence heads away from where it finished.
+func (seq *Sequence) Toggle() {
+	seq.head(!seq.backward())
+}
+
+// Rewind jumps the Sequence back to where playing in the direction it is heading
+// starts, with all of its loops left to play. It is the opposite of Complete. Yoyo
+// Sequences always start forward from the beginning.
+func (seq *Sequence) Rewind() {
+	if !seq.HasTweens() {
+		return
+	}
+	seq.reverse = seq.reverse && !seq.yoyo
+	for _, tween := range seq.Tweens {
+		tween.rewind(seq.reverse)
+	}
+	seq.Reset()
+	if seq.reverse {
+		seq.index = len(seq.Tweens) - 1
+		if seq.loopMode == LoopIncremental && seq.loop > 1 {
+			// Playing backward starts from where the last loop ends
+			seq.increment(1 - seq.loop)
+		}
+	}
+}
+
+// head makes the Sequence head towards its beginning, or its end.
+func (seq *Sequence) head(reverse bool) {
+	if !seq.HasTweens() {
+		return
+	}
+	switch {
+	case seq.finished():
+		if reverse != seq.atEnd() {
+			// Already where it is heading
+			return
+		}
+		seq.loopRemaining = seq.loop
+		seq.completedLoops = 0
+		seq.turned = false
+		seq.reverse = reverse
+		seq.index = seq.clampIndex(seq.index)
+		seq.enter(seq.index)
+		seq.atStart = true
+	case reverse == seq.reverse:
+	case seq.yoyo && (seq.index < 0 || seq.index >= len(seq.Tweens) && seq.yoyoLoops.HalfCycles):
+		// Waiting at the end of a loop, so move on to the next one as Update would
+		if seq.countsLoop() {
+			seq.consumeLoop()
+		}
+		seq.reverse = reverse
+		seq.turned = false
+		seq.index = seq.clampIndex(seq.index)
+		seq.enter(seq.index)
+	default:
+		if seq.yoyo {
+			seq.turned = true
+		} else if seq.loop > 0 {
+			seq.loopRemaining = seq.completedLoops + 1
+			seq.completedLoops = seq.loop - seq.loopRemaining
+		}
+		seq.reverse = reverse
+		seq.index = seq.clampIndex(seq.index)
+		seq.Tweens[seq.index].head(reverse)
+	}
+}
+
+// backward returns whether the Sequence is heading towards its beginning, which a
+// finished Sequence is if it finished there.
+func (seq *Sequence) backward() bool {
+	if seq.HasTweens() && seq.finished() {
+		return !seq.atEnd()
+	}
+	return seq.reverse
+}
+
+// atEnd returns whether the Sequence is at the end of its last Tween.
+func (seq *Sequence) atEnd() bool {
+	if seq.index >= len(seq.Tweens) {
+		return true
+	}
+	last := seq.Tweens[len(seq.Tweens)-1]
+	return seq.index == len(seq.Tweens)-1 && last.time >= last.duration
+}
@@ -0,0 +1,341 @@
+package gween
+
+import (
+	"testing"
+	"testing/quick"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tanema/gween/ease"
+)
+
+func TestTween_PlayBackward(t *testing.T) {
+	tween := New(0, 10, 1, ease.Linear)
+	tween.Update(0.25)
+	tween.PlayBackward()
+	assert.True(t, tween.Reverse())
+	current, isFinished := tween.Update(0.125)
+	assert.Equal(t, float32(1.25), current)
+	assert.False(t, isFinished)
+	current, isFinished = tween.Update(1)
+	assert.Equal(t, float32(0), current)
+	assert.True(t, isFinished)
+
+	// Already at the beginning
+	tween.PlayBackward()
+	current, isFinished = tween.Update(1)
+	assert.Equal(t, float32(0), current)
+	assert.True(t, isFinished)
+
+	tween.PlayForward()
+	assert.False(t, tween.Reverse())
+	current, isFinished = tween.Update(0.5)
+	assert.Equal(t, float32(5), current)
+	assert.False(t, isFinished)
+}
+
+func TestTween_Toggle(t *testing.T) {
+	tween := New(0, 10, 1, ease.Linear)
+	tween.Update(0.5)
+	tween.Toggle()
+	current, isFinished := tween.Update(0.5)
+	assert.Equal(t, float32(0), current)
+	assert.True(t, isFinished)
+
+	// Finished Tweens head away from where they finished
+	tween.Toggle()
+	current, isFinished = tween.Update(1)
+	assert.Equal(t, float32(10), current)
+	assert.True(t, isFinished)
+	tween.Toggle()
+	current, _ = tween.Update(0.25)
+	assert.Equal(t, float32(7.5), current)
+
+	instant := New(0, 10, 0, ease.Linear)
+	instant.Toggle()
+	assert.Equal(t, float32(0), instant.Value())
+}
+
+func TestTween_PlayBackwardLoops(t *testing.T) {
+	tween := New(0, 10, 1, ease.Linear)
+	tween.SetLoop(3)
+	tween.Update(1.5)
+	tween.PlayBackward()
+	assert.Equal(t, 2, tween.LoopsRemaining())
+	assert.Equal(t, 1, tween.CurrentLoop())
+	current, isFinished := tween.Update(1)
+	assert.Equal(t, float32(5), current)
+	assert.False(t, isFinished)
+	assert.Equal(t, 1, tween.LoopsRemaining())
+	current, isFinished = tween.Update(1)
+	assert.Equal(t, float32(0), current)
+	assert.True(t, isFinished)
+
+	// Plays every loop again from where it finished
+	tween.PlayForward()
+	assert.Equal(t, 3, tween.LoopsRemaining())
+	current, isFinished = tween.Update(2.5)
+	assert.Equal(t, float32(5), current)
+	assert.False(t, isFinished)
+
+	yoyo := New(0, 10, 1, ease.Linear)
+	yoyo.SetLoopMode(LoopYoyo)
+	yoyo.Update(0.5)
+	yoyo.PlayBackward()
+	current, isFinished = yoyo.Update(0.5)
+	assert.Equal(t, float32(0), current)
+	assert.True(t, isFinished)
+	assert.Equal(t, 0, yoyo.LoopsRemaining())
+}
+
+func TestTween_Rewind(t *testing.T) {
+	tween := New(0, 10, 1, ease.Linear)
+	tween.SetLoopMode(LoopIncremental)
+	tween.SetLoop(3)
+	tween.Complete()
+	assert.Equal(t, float32(30), tween.Value())
+	tween.PlayBackward()
+	current, isFinished := tween.Update(3)
+	assert.Equal(t, float32(0), current)
+	assert.True(t, isFinished)
+
+	// Playing backward starts from the end of the last loop
+	tween.Rewind()
+	assert.Equal(t, float32(30), tween.Value())
+	assert.Equal(t, 3, tween.LoopsRemaining())
+	current, _ = tween.Update(1.5)
+	assert.Equal(t, float32(15), current)
+
+	tween.PlayForward()
+	tween.Rewind()
+	assert.Equal(t, float32(0), tween.Value())
+	assert.Equal(t, 3, tween.LoopsRemaining())
+}
+
+func TestTween_CompleteAnyState(t *testing.T) {
+	// Mid-loop, after turning around
+	tween := New(0, 10, 1, ease.Linear)
+	tween.SetLoop(3)
+	tween.Update(1.5)
+	tween.PlayBackward()
+	tween.Complete()
+	assert.Equal(t, float32(0), tween.Value())
+	assert.Equal(t, 0, tween.LoopsRemaining())
+	current, isFinished := tween.Update(1)
+	assert.Equal(t, float32(0), current)
+	assert.True(t, isFinished)
+
+	// Already finished
+	tween.Complete()
+	assert.Equal(t, float32(0), tween.Value())
+	tween.PlayForward()
+	assert.Equal(t, 3, tween.LoopsRemaining())
+	current, isFinished = tween.Update(2.5)
+	assert.Equal(t, float32(5), current)
+	assert.False(t, isFinished)
+
+	// Waiting at the end of a loop
+	tween.Rewind()
+	tween.Update(1)
+	tween.Complete()
+	assert.Equal(t, float32(10), tween.Value())
+	assert.Equal(t, 0, tween.LoopsRemaining())
+}
+
+func TestSequence_PlayBackward(t *testing.T) {
+	seq := NewSequence(New(0, 1, 1, ease.Linear), New(1, 2, 1, ease.Linear))
+	seq.SetLoop(2)
+	current, _, isFinished := seq.Update(4)
+	assert.Equal(t, float32(2), current)
+	assert.True(t, isFinished)
+
+	// Plays every loop again from where it finished
+	seq.PlayBackward()
+	assert.True(t, seq.Reverse())
+	assert.Equal(t, 2, seq.LoopsRemaining())
+	current, _, isFinished = seq.Update(2.5)
+	assert.Equal(t, float32(1.5), current)
+	assert.False(t, isFinished)
+	assert.Equal(t, 1, seq.CurrentLoop())
+	current, _, isFinished = seq.Update(2)
+	assert.Equal(t, float32(0), current)
+	assert.True(t, isFinished)
+
+	// Already at the beginning
+	seq.PlayBackward()
+	current, _, isFinished = seq.Update(1)
+	assert.Equal(t, float32(0), current)
+	assert.True(t, isFinished)
+
+	seq.Toggle()
+	assert.False(t, seq.Reverse())
+	current, _, isFinished = seq.Update(1.25)
+	assert.Equal(t, float32(1.25), current)
+	assert.False(t, isFinished)
+}
+
+func TestSequence_PlayBackwardMidLoop(t *testing.T) {
+	seq := NewSequence(New(0, 1, 1, ease.Linear), New(1, 2, 1, ease.Linear))
+	seq.SetLoop(3)
+	seq.Update(2.5)
+	seq.PlayBackward()
+	assert.Equal(t, 2, seq.LoopsRemaining())
+	current, _, isFinished := seq.Update(1)
+	assert.Equal(t, float32(1.5), current)
+	assert.False(t, isFinished)
+	current, _, isFinished = seq.Update(1.5)
+	assert.Equal(t, float32(0), current)
+	assert.True(t, isFinished)
+
+	// Between loops, with the index past the last Tween
+	seq.PlayForward()
+	seq.SetLoop(2)
+	seq.Rewind()
+	seq.Update(2)
+	assert.Equal(t, 2, seq.Index())
+	seq.PlayBackward()
+	current, _, isFinished = seq.Update(0.5)
+	assert.Equal(t, float32(1.5), current)
+	assert.False(t, isFinished)
+	assert.Equal(t, 1, seq.LoopsRemaining())
+	current, _, isFinished = seq.Update(1.5)
+	assert.Equal(t, float32(0), current)
+	assert.True(t, isFinished)
+}
+
+func TestSequence_PlayForwardYoyo(t *testing.T) {
+	seq := NewSequence(New(0, 1, 1, ease.Linear), New(1, 2, 1, ease.Linear))
+	seq.SetYoyo(true)
+	seq.Update(3)
+	seq.PlayForward()
+	current, _, isFinished := seq.Update(0.5)
+	assert.Equal(t, float32(1.5), current)
+	assert.False(t, isFinished)
+	current, _, isFinished = seq.Update(2.5)
+	assert.Equal(t, float32(0), current)
+	assert.True(t, isFinished)
+
+	seq.PlayForward()
+	current, _, isFinished = seq.Update(1)
+	assert.Equal(t, float32(1), current)
+	assert.False(t, isFinished)
+	assert.Equal(t, 1, seq.LoopsRemaining())
+
+	// Waiting at the beginning between loops
+	seq.SetLoop(2)
+	seq.Rewind()
+	seq.Update(4)
+	assert.Equal(t, 2, seq.LoopsRemaining())
+	seq.PlayForward()
+	assert.False(t, seq.Reverse())
+	assert.Equal(t, 1, seq.LoopsRemaining())
+	assert.Equal(t, 1, seq.CurrentLoop())
+	current, _, isFinished = seq.Update(0.5)
+	assert.Equal(t, float32(0.5), current)
+	assert.False(t, isFinished)
+}
+
+func TestSequence_Rewind(t *testing.T) {
+	seq := NewSequence(New(0, 1, 1, ease.Linear), New(1, 2, 1, ease.Linear))
+	seq.SetLoopMode(LoopIncremental)
+	seq.SetLoop(2)
+	seq.Update(1.5)
+	seq.PlayBackward()
+	seq.Rewind()
+	assert.Equal(t, float32(4), seq.Value())
+	assert.Equal(t, 1, seq.Index())
+	assert.Equal(t, 2, seq.LoopsRemaining())
+	current, _, isFinished := seq.Update(2.5)
+	assert.Equal(t, float32(1.5), current)
+	assert.False(t, isFinished)
+
+	seq.Complete()
+	assert.Equal(t, float32(0), seq.Value())
+	seq.PlayForward()
+	seq.Rewind()
+	assert.Equal(t, float32(0), seq.Value())
+	assert.Equal(t, 0, seq.Index())
+
+	empty := NewSequence()
+	empty.PlayForward()
+	empty.PlayBackward()
+	empty.Toggle()
+	empty.Rewind()
+	empty.Complete()
+	empty.SetReverse(true)
+	assert.True(t, empty.Reverse())
+}
+
+func TestSequence_CompleteAnyState(t *testing.T) {
+	// Mid-loop, after turning around
+	seq := NewSequence(New(0, 1, 1, ease.Linear), New(1, 2, 1, ease.Linear))
+	seq.SetLoop(3)
+	seq.Update(2.5)
+	seq.PlayBackward()
+	seq.Complete()
+	assert.Equal(t, float32(0), seq.Value())
+	assert.Equal(t, 0, seq.LoopsRemaining())
+	current, _, isFinished := seq.Update(1)
+	assert.Equal(t, float32(0), current)
+	assert.True(t, isFinished)
+
+	// Already finished
+	seq.Complete()
+	assert.Equal(t, float32(0), seq.Value())
+	seq.PlayForward()
+	assert.Equal(t, 3, seq.LoopsRemaining())
+	current, _, isFinished = seq.Update(2.5)
+	assert.Equal(t, float32(0.5), current)
+	assert.False(t, isFinished)
+
+	// With the index past the last Tween, between loops
+	seq.Rewind()
+	seq.Update(2)
+	assert.Equal(t, 2, seq.Index())
+	seq.Complete()
+	assert.Equal(t, float32(2), seq.Value())
+	assert.Equal(t, 0, seq.LoopsRemaining())
+	assert.Equal(t, 2, seq.CurrentLoop())
+	current, _, isFinished = seq.Update(1)
+	assert.Equal(t, float32(2), current)
+	assert.True(t, isFinished)
+
+	// With the index before the first Tween, between loops
+	seq.PlayBackward()
+	seq.Update(2)
+	assert.Equal(t, -1, seq.Index())
+	seq.Complete()
+	assert.Equal(t, float32(0), seq.Value())
+	assert.Equal(t, 0, seq.LoopsRemaining())
+	current, _, isFinished = seq.Update(1)
+	assert.Equal(t, float32(0), current)
+	assert.True(t, isFinished)
+}
+
+func TestSequence_PlayBackwardAnywhere(t *testing.T) {
+	// Turned around at any point, a Sequence that does not yoyo takes as long to
+	// get back to its beginning as it played for
+	property := func(c loopCase) bool {
+		c.Yoyo = false
+		seq := c.sequence()
+		var time float32
+		for _, step := range c.Steps {
+			time += step
+			seq.Update(step)
+		}
+		if total := c.duration() * float32(c.Loop); time > total {
+			time = total
+		}
+		seq.PlayBackward()
+		const epsilon = float32(1) / 128
+		if _, _, isFinished := seq.Update(time - epsilon); isFinished {
+			t.Logf("%+v finished early", c)
+			return false
+		}
+		current, _, isFinished := seq.Update(epsilon)
+		return assert.True(t, isFinished, "%+v", c) && assert.Equal(t, float32(0), current, "%+v", c)
+	}
+	if err := quick.Check(property, quickConfig); err != nil {
+		t.Error(err)
+	}
+}
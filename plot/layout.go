@@ -0,0 +1,74 @@
+package plot
+
+import (
+	"image/color"
+	"math"
+	"strconv"
+)
+
+const (
+	marginLeft   = 60
+	marginRight  = 20
+	marginTop    = 30
+	marginBottom = 35
+	tickCount    = 5
+)
+
+var (
+	// palette holds the colors that curves are drawn with, in order.
+	palette = []color.RGBA{
+		{R: 0x1f, G: 0x77, B: 0xb4, A: 0xff},
+		{R: 0xff, G: 0x7f, B: 0x0e, A: 0xff},
+		{R: 0x2c, G: 0xa0, B: 0x2c, A: 0xff},
+		{R: 0xd6, G: 0x27, B: 0x28, A: 0xff},
+		{R: 0x94, G: 0x67, B: 0xbd, A: 0xff},
+		{R: 0x8c, G: 0x56, B: 0x4b, A: 0xff},
+		{R: 0xe3, G: 0x77, B: 0xc2, A: 0xff},
+		{R: 0x7f, G: 0x7f, B: 0x7f, A: 0xff},
+	}
+	background = color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+	overshoot  = color.RGBA{R: 0xfb, G: 0xe3, B: 0xe3, A: 0xff}
+	foreground = color.RGBA{R: 0x33, G: 0x33, B: 0x33, A: 0xff}
+	grid       = color.RGBA{R: 0xdd, G: 0xdd, B: 0xdd, A: 0xff}
+)
+
+// layout maps values of a Plot to pixels within its image.
+type layout struct {
+	bounds
+	left, top, right, bottom float64
+}
+
+func (plot *Plot) layout() layout {
+	return layout{
+		bounds: plot.bounds(),
+		left:   marginLeft,
+		top:    marginTop,
+		right:  float64(plot.Width - marginRight),
+		bottom: float64(plot.Height - marginBottom),
+	}
+}
+
+// x returns the horizontal pixel position of time.
+func (l layout) x(time float64) float64 {
+	return l.left + (time-l.minX)/(l.maxX-l.minX)*(l.right-l.left)
+}
+
+// y returns the vertical pixel position of value.
+func (l layout) y(value float64) float64 {
+	return l.bottom - (value-l.minY)/(l.maxY-l.minY)*(l.bottom-l.top)
+}
+
+// colorOf returns the color the curve at index is drawn with.
+func colorOf(index int) color.RGBA {
+	return palette[index%len(palette)]
+}
+
+// label formats a tick value for display.
+func label(value float64) string {
+	value = math.Round(value*100) / 100
+	if value == 0 {
+		// avoid printing negative zero
+		value = 0
+	}
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
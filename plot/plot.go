@@ -0,0 +1,232 @@
+// Package plot renders easing functions and the values of Sequences over time
+// to SVG and PNG images, using only the standard library. Plots have labelled
+// axes, shade the regions where a curve overshoots the values it animates
+// between, and can compare several curves at once.
+package plot
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/tanema/gween"
+	"github.com/tanema/gween/ease"
+)
+
+// DefaultSamples is the number of samples taken along a curve when a
+// non-positive number of samples is requested.
+const DefaultSamples = 200
+
+type (
+	// Point is a single sample of a Curve, the value Y at time X.
+	Point struct {
+		X, Y float64
+	}
+
+	// Curve is a sampled curve to be drawn on a Plot. Low and High are the values
+	// the curve animates between, anything outside of them is overshoot.
+	Curve struct {
+		Name      string
+		Points    []Point
+		Low, High float64
+	}
+
+	// Plot is a set of Curves drawn on the same axes.
+	Plot struct {
+		Title  string
+		Width  int
+		Height int
+		Curves []Curve
+	}
+
+	// bounds is the range of values covered by the axes of a Plot.
+	bounds struct {
+		minX, maxX, minY, maxY float64
+		low, high              float64
+		// over and under are whether any curve goes above high or below low
+		over, under bool
+	}
+)
+
+// New returns a new Plot of the provided curves, 480 by 320 pixels in size.
+func New(title string, curves ...Curve) *Plot {
+	return &Plot{
+		Title:  title,
+		Width:  480,
+		Height: 320,
+		Curves: curves,
+	}
+}
+
+// Add adds one or more curves to the Plot.
+func (plot *Plot) Add(curves ...Curve) {
+	plot.Curves = append(plot.Curves, curves...)
+}
+
+// Ease samples the easing function from 0 to 1 over a duration of 1.
+func Ease(name string, fn ease.TweenFunc, samples int) Curve {
+	if samples <= 0 {
+		samples = DefaultSamples
+	}
+	curve := Curve{Name: name, Points: make([]Point, samples+1), Low: 0, High: 1}
+	for i := range curve.Points {
+		t := float32(i) / float32(samples)
+		curve.Points[i] = Point{X: float64(t), Y: float64(fn(t, 0, 1, 1))}
+	}
+	return curve
+}
+
+// Tween samples the value of the Tween from its beginning to its end, by seeking
+// a copy of it to each sample. The Tween itself is left untouched.
+func Tween(name string, tween *gween.Tween, samples int) Curve {
+	if samples <= 0 {
+		samples = DefaultSamples
+	}
+	sample := *tween
+	curve := Curve{Name: name, Points: make([]Point, samples+1)}
+	for i := range curve.Points {
+		t := tween.Duration() * float32(i) / float32(samples)
+		sample.Seek(t)
+		curve.Points[i] = Point{X: float64(t), Y: float64(sample.Value())}
+	}
+	curve.Low = math.Min(float64(tween.Begin()), float64(tween.End()))
+	curve.High = math.Max(float64(tween.Begin()), float64(tween.End()))
+	return curve
+}
+
+// Animation samples a Tween or Sequence, as with Tween and Sequence.
+func Animation(name string, animation gween.Animation, samples int) Curve {
+	switch animation := animation.(type) {
+	case *gween.Tween:
+		return Tween(name, animation, samples)
+	case *gween.Sequence:
+		return Sequence(name, animation, samples)
+	}
+	return Curve{Name: name}
+}
+
+// Sequence samples the value of the Sequence from its beginning until it
+// completes, by seeking a copy of it to each sample. The Sequence itself is left
+// untouched: no callbacks are called, no markers are passed and the Tweens of a
+// LoopShuffle Sequence keep their order, although the copy shuffles them with a
+// source of randomness of its own. Sequences that loop forever are sampled for a
+// single loop.
+func Sequence(name string, seq *gween.Sequence, samples int) Curve {
+	if samples <= 0 {
+		samples = DefaultSamples
+	}
+	curve := Curve{Name: name, Points: make([]Point, samples+1)}
+	if !seq.HasTweens() {
+		return Curve{Name: name}
+	}
+	duration := sequenceDuration(seq)
+	seq = copySequence(seq)
+	curve.Low, curve.High = math.Inf(1), math.Inf(-1)
+	for i := range curve.Points {
+		t := duration * float32(i) / float32(samples)
+		seq.Seek(t)
+		curve.Points[i] = Point{X: float64(t), Y: float64(seq.Value())}
+		// Relative tweens are only resolved once they have been reached
+		tween := seq.Tweens[clamp(seq.Index(), 0, len(seq.Tweens)-1)]
+		curve.Low = math.Min(curve.Low, math.Min(float64(tween.Begin()), float64(tween.End())))
+		curve.High = math.Max(curve.High, math.Max(float64(tween.Begin()), float64(tween.End())))
+	}
+	return curve
+}
+
+// copySequence returns a copy of the Sequence and its Tweens that can be played
+// without side effects on the original.
+func copySequence(seq *gween.Sequence) *gween.Sequence {
+	tweens := make([]*gween.Tween, len(seq.Tweens))
+	for i, tween := range seq.Tweens {
+		if tween.Kind() == gween.KindCallback {
+			tweens[i] = gween.NewCallback(func() {})
+			continue
+		}
+		copied := *tween
+		tweens[i] = &copied
+	}
+	sample := gween.NewSequence(tweens...)
+	state := seq.Snapshot()
+	// The copies are already in the order of the original, so the only error,
+	// a different number of Tweens, cannot happen
+	state.Order = nil
+	sample.Restore(state)
+	sample.SetShuffleRand(rand.New(rand.NewSource(1)))
+	return sample
+}
+
+// sequenceDuration returns how long the Sequence takes to complete every loop,
+// or a single loop when it loops forever.
+func sequenceDuration(seq *gween.Sequence) float32 {
+	var duration float32
+	for _, tween := range seq.Tweens {
+		if scale := tween.TimeScale(); scale > 0 {
+			duration += tween.Duration() / scale
+		}
+	}
+	if seq.Yoyo() && !seq.YoyoLoops().HalfCycles {
+		// A loop is a trip out to the end and back, unless each trip is a loop
+		duration *= 2
+	}
+	if seq.Loop() > 1 {
+		duration *= float32(seq.Loop())
+	}
+	return duration
+}
+
+// bounds returns the range of values covered by the axes of the Plot, which
+// includes every point of every curve with a little padding for overshoot.
+func (plot *Plot) bounds() bounds {
+	b := bounds{
+		minX: math.Inf(1), maxX: math.Inf(-1),
+		minY: math.Inf(1), maxY: math.Inf(-1),
+		low: math.Inf(1), high: math.Inf(-1),
+	}
+	for _, curve := range plot.Curves {
+		if len(curve.Points) == 0 {
+			continue
+		}
+		b.low = math.Min(b.low, curve.Low)
+		b.high = math.Max(b.high, curve.High)
+		for _, point := range curve.Points {
+			if math.IsNaN(point.Y) || math.IsInf(point.Y, 0) {
+				continue
+			}
+			b.minX, b.maxX = math.Min(b.minX, point.X), math.Max(b.maxX, point.X)
+			b.minY, b.maxY = math.Min(b.minY, point.Y), math.Max(b.maxY, point.Y)
+		}
+	}
+	if math.IsInf(b.minX, 0) {
+		return bounds{maxX: 1, maxY: 1, high: 1}
+	}
+	b.over, b.under = b.maxY > b.high, b.minY < b.low
+	b.minY, b.maxY = math.Min(b.minY, b.low), math.Max(b.maxY, b.high)
+	if b.maxX == b.minX {
+		b.maxX = b.minX + 1
+	}
+	if b.maxY == b.minY {
+		b.minY, b.maxY = b.minY-0.5, b.maxY+0.5
+	}
+	padding := (b.maxY - b.minY) * 0.05
+	b.minY, b.maxY = b.minY-padding, b.maxY+padding
+	return b
+}
+
+// ticks returns n+1 evenly spaced values from min to max.
+func ticks(min, max float64, n int) []float64 {
+	values := make([]float64, n+1)
+	for i := range values {
+		values[i] = min + (max-min)*float64(i)/float64(n)
+	}
+	return values
+}
+
+func clamp(value, min, max int) int {
+	if value < min {
+		return min
+	}
+	if value > max {
+		return max
+	}
+	return value
+}
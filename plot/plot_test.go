@@ -0,0 +1,144 @@
+package plot
+
+import (
+	"bytes"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tanema/gween"
+	"github.com/tanema/gween/ease"
+)
+
+func TestEase(t *testing.T) {
+	curve := Ease("Linear", ease.Linear, 4)
+	assert.Equal(t, "Linear", curve.Name)
+	assert.Equal(t, []Point{{0, 0}, {0.25, 0.25}, {0.5, 0.5}, {0.75, 0.75}, {1, 1}}, curve.Points)
+	assert.Equal(t, float64(0), curve.Low)
+	assert.Equal(t, float64(1), curve.High)
+	assert.Len(t, Ease("Linear", ease.Linear, 0).Points, DefaultSamples+1)
+}
+
+func TestSequence(t *testing.T) {
+	seq := gween.NewSequence(gween.New(0, 10, 1, ease.Linear), gween.NewBy(-20, 1, ease.Linear))
+	seq.SetYoyo(true)
+	seq.Update(0.5)
+	curve := Sequence("seq", seq, 8)
+	assert.Len(t, curve.Points, 9)
+	assert.Equal(t, Point{0, 0}, curve.Points[0])
+	assert.Equal(t, Point{0.5, 5}, curve.Points[1])
+	assert.Equal(t, Point{1.5, 0}, curve.Points[3])
+	assert.Equal(t, Point{2.5, 0}, curve.Points[5])
+	assert.Equal(t, Point{3.5, 5}, curve.Points[7])
+	assert.Equal(t, Point{4, 0}, curve.Points[8])
+	assert.Equal(t, float64(-10), curve.Low)
+	assert.Equal(t, float64(10), curve.High)
+	assert.Equal(t, float32(5), seq.Value())
+
+	assert.Len(t, Sequence("empty", gween.NewSequence(), 8).Points, 0)
+}
+
+func TestSequence_HalfCycles(t *testing.T) {
+	seq := gween.NewSequence(gween.New(0, 10, 1, ease.Linear), gween.New(10, 20, 1, ease.Linear))
+	seq.SetYoyo(true)
+	seq.SetYoyoLoops(gween.YoyoLoops{HalfCycles: true})
+	seq.SetLoop(3)
+	curve := Sequence("seq", seq, 6)
+	assert.Equal(t, Point{6, 20}, curve.Points[6])
+	assert.Equal(t, Point{3, 10}, curve.Points[3])
+}
+
+func TestSequence_SideEffects(t *testing.T) {
+	calls := 0
+	count := func() { calls++ }
+	first, second := gween.New(0, 10, 1, ease.Linear), gween.New(10, 0, 1, ease.Linear)
+	seq := gween.NewSequence(first, gween.NewCallback(count), second)
+	seq.SetLoopMode(gween.LoopShuffle)
+	seq.SetLoop(3)
+	seq.AddMarker("middle", 1)
+	seq.SetOnMarker(func(gween.Marker, gween.Direction) { count() })
+	seq.SetOnTweenComplete(func(int) { count() })
+	curve := Sequence("seq", seq, 24)
+	assert.Len(t, curve.Points, 25)
+	assert.Equal(t, 0, calls)
+	assert.Same(t, first, seq.Tweens[0])
+	assert.Same(t, second, seq.Tweens[2])
+	assert.Equal(t, 0, seq.Index())
+	assert.Equal(t, float32(0), seq.Value())
+}
+
+func TestPlot_SVG(t *testing.T) {
+	plot := New("Back & Quad", Ease("OutBack", ease.OutBack, 0))
+	plot.Add(Ease("InQuad", ease.InQuad, 0))
+	var buf bytes.Buffer
+	assert.Nil(t, plot.SVG(&buf))
+	svg := buf.String()
+	assert.True(t, strings.HasPrefix(svg, "<svg"))
+	assert.Equal(t, 2, strings.Count(svg, `class="curve"`))
+	assert.Equal(t, 1, strings.Count(svg, `class="overshoot"`))
+	assert.Contains(t, svg, "Back &amp; Quad")
+	assert.Contains(t, svg, ">OutBack<")
+	assert.Contains(t, svg, ">InQuad<")
+
+	buf.Reset()
+	assert.Nil(t, New("", Ease("Linear", ease.Linear, 0)).SVG(&buf))
+	assert.NotContains(t, buf.String(), `class="overshoot"`)
+}
+
+func TestPlot_PNG(t *testing.T) {
+	plot := New("", Ease("OutBack", ease.OutBack, 0), Ease("InQuad", ease.InQuad, 0))
+	plot.Width, plot.Height = 300, 200
+	var buf bytes.Buffer
+	assert.Nil(t, plot.PNG(&buf))
+	img, err := png.Decode(&buf)
+	assert.Nil(t, err)
+	assert.Equal(t, 300, img.Bounds().Dx())
+	assert.Equal(t, 200, img.Bounds().Dy())
+
+	l := plot.layout()
+	r, g, b, _ := img.At(int(l.x(1)), int(l.y(1))).RGBA()
+	assert.NotEqual(t, [3]uint32{0xffff, 0xffff, 0xffff}, [3]uint32{r, g, b})
+	r, g, b, _ = img.At(int(l.right)-2, int(l.top)+2).RGBA()
+	assert.Equal(t, [3]uint32{uint32(overshoot.R) * 0x101, uint32(overshoot.G) * 0x101, uint32(overshoot.B) * 0x101}, [3]uint32{r, g, b})
+}
+
+func TestTween(t *testing.T) {
+	tween := gween.New(0, 10, 2, ease.Linear)
+	tween.Update(1)
+	curve := Tween("tween", tween, 4)
+	assert.Equal(t, []Point{{0, 0}, {0.5, 2.5}, {1, 5}, {1.5, 7.5}, {2, 10}}, curve.Points)
+	assert.Equal(t, float64(0), curve.Low)
+	assert.Equal(t, float64(10), curve.High)
+	assert.Equal(t, float32(5), tween.Value())
+	assert.Equal(t, curve, Animation("tween", tween, 4))
+}
+
+func TestPlot_ASCII(t *testing.T) {
+	var buf bytes.Buffer
+	assert.Nil(t, New("Linear", Ease("Linear", ease.Linear, 0)).ASCII(&buf, 10, 5))
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	assert.Len(t, lines, 8)
+	assert.Equal(t, "      Linear", lines[0])
+	assert.Equal(t, " 1.05|* * * * **", lines[1])
+	assert.Equal(t, "-0.05|*** * * * ", lines[5])
+	assert.Equal(t, "     +----------", lines[6])
+	assert.Equal(t, "      0        1", lines[7])
+
+	assert.NotNil(t, New("").ASCII(&buf, 1, 1))
+}
+
+func TestPlot_Braille(t *testing.T) {
+	var buf bytes.Buffer
+	assert.Nil(t, New("", Ease("Linear", ease.Linear, 0)).Braille(&buf, 10, 5))
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	assert.Len(t, lines, 7)
+	for _, line := range lines[:5] {
+		assert.Equal(t, 16, len([]rune(line)))
+	}
+	// the curve rises from the bottom left to the top right
+	assert.NotEqual(t, ' ', []rune(lines[4])[6])
+	assert.NotEqual(t, ' ', []rune(lines[0])[15])
+	assert.Equal(t, ' ', []rune(lines[2])[15])
+}
@@ -0,0 +1,143 @@
+package plot
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"math"
+)
+
+// glyphs holds a 3 by 5 pixel bitmap of each character used in tick labels, one
+// row per element with the leftmost pixel in the highest of the three bits.
+var glyphs = map[rune][5]uint8{
+	'0': {7, 5, 5, 5, 7},
+	'1': {2, 6, 2, 2, 7},
+	'2': {7, 1, 7, 4, 7},
+	'3': {7, 1, 3, 1, 7},
+	'4': {5, 5, 7, 1, 1},
+	'5': {7, 4, 7, 1, 7},
+	'6': {7, 4, 7, 5, 7},
+	'7': {7, 1, 1, 1, 1},
+	'8': {7, 5, 7, 5, 7},
+	'9': {7, 5, 7, 1, 7},
+	'.': {0, 0, 0, 0, 2},
+	'-': {0, 0, 7, 0, 0},
+	'+': {0, 2, 7, 2, 0},
+	'e': {0, 7, 7, 4, 7},
+}
+
+// glyphScale is the size in pixels of each pixel of a glyph.
+const glyphScale = 2
+
+// PNG writes the Plot to w as a PNG image. Tick labels are drawn with a small
+// built in font, but as the standard library cannot render text the title and
+// curve names are only included in SVG output; each curve is drawn in the same
+// color as in SVG output.
+func (plot *Plot) PNG(w io.Writer) error {
+	return png.Encode(w, plot.Image())
+}
+
+// Image draws the Plot to a new image.
+func (plot *Plot) Image() *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, plot.Width, plot.Height))
+	draw.Draw(img, img.Bounds(), image.NewUniform(background), image.Point{}, draw.Src)
+	l := plot.layout()
+
+	if l.over {
+		fill(img, l.left, l.top, l.right, l.y(l.high), overshoot)
+	}
+	if l.under {
+		fill(img, l.left, l.y(l.low), l.right, l.bottom, overshoot)
+	}
+
+	for _, value := range ticks(l.minX, l.maxX, tickCount) {
+		x := l.x(value)
+		line(img, x, l.top, x, l.bottom, 1, grid)
+		line(img, x, l.bottom, x, l.bottom+4, 1, foreground)
+		text := label(value)
+		write(img, x-float64(textWidth(text))/2, l.bottom+8, text)
+	}
+	for _, value := range ticks(l.minY, l.maxY, tickCount) {
+		y := l.y(value)
+		line(img, l.left, y, l.right, y, 1, grid)
+		line(img, l.left-4, y, l.left, y, 1, foreground)
+		text := label(value)
+		write(img, l.left-8-float64(textWidth(text)), y-5*glyphScale/2, text)
+	}
+	for _, value := range []float64{l.low, l.high} {
+		y := l.y(value)
+		for x := l.left; x < l.right; x += 7 {
+			line(img, x, y, math.Min(x+4, l.right), y, 1, foreground)
+		}
+	}
+	line(img, l.left, l.top, l.left, l.bottom, 1, foreground)
+	line(img, l.left, l.bottom, l.right, l.bottom, 1, foreground)
+
+	for i, curve := range plot.Curves {
+		var previous *Point
+		for j := range curve.Points {
+			point := curve.Points[j]
+			if math.IsNaN(point.Y) || math.IsInf(point.Y, 0) {
+				continue
+			}
+			if previous != nil {
+				line(img, l.x(previous.X), l.y(previous.Y), l.x(point.X), l.y(point.Y), 2, colorOf(i))
+			}
+			previous = &curve.Points[j]
+		}
+	}
+	return img
+}
+
+// fill fills the rectangle between the two corners with c.
+func fill(img *image.RGBA, x0, y0, x1, y1 float64, c color.RGBA) {
+	rect := image.Rect(int(math.Round(x0)), int(math.Round(y0)), int(math.Round(x1)), int(math.Round(y1)))
+	draw.Draw(img, rect, image.NewUniform(c), image.Point{}, draw.Src)
+}
+
+// line draws a line of the provided width in pixels between the two points.
+func line(img *image.RGBA, x0, y0, x1, y1 float64, width int, c color.RGBA) {
+	steps := int(math.Ceil(math.Max(math.Abs(x1-x0), math.Abs(y1-y0))))
+	for i := 0; i <= steps; i++ {
+		t := 0.0
+		if steps > 0 {
+			t = float64(i) / float64(steps)
+		}
+		x := int(math.Round(x0 + (x1-x0)*t))
+		y := int(math.Round(y0 + (y1-y0)*t))
+		for dx := 0; dx < width; dx++ {
+			for dy := 0; dy < width; dy++ {
+				img.SetRGBA(x+dx-width/2, y+dy-width/2, c)
+			}
+		}
+	}
+}
+
+// write draws text with its top left corner at the provided point.
+func write(img *image.RGBA, x, y float64, text string) {
+	left, top := int(math.Round(x)), int(math.Round(y))
+	for _, r := range text {
+		glyph := glyphs[r]
+		for row, bits := range glyph {
+			for col := 0; col < 3; col++ {
+				if bits&(4>>uint(col)) == 0 {
+					continue
+				}
+				fill(img, float64(left+col*glyphScale), float64(top+row*glyphScale),
+					float64(left+(col+1)*glyphScale), float64(top+(row+1)*glyphScale), foreground)
+			}
+		}
+		left += 4 * glyphScale
+	}
+}
+
+// textWidth returns the width in pixels of text drawn with write.
+func textWidth(text string) int {
+	n := len([]rune(text))
+	if n == 0 {
+		return 0
+	}
+	return n*4*glyphScale - glyphScale
+}
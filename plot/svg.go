@@ -0,0 +1,80 @@
+package plot
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"image/color"
+	"io"
+	"math"
+	"strings"
+)
+
+// SVG writes the Plot to w as an SVG image.
+func (plot *Plot) SVG(w io.Writer) error {
+	out := bufio.NewWriter(w)
+	l := plot.layout()
+
+	fmt.Fprintf(out, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif" font-size="11">`+"\n",
+		plot.Width, plot.Height, plot.Width, plot.Height)
+	fmt.Fprintf(out, `<rect width="100%%" height="100%%" fill="%s"/>`+"\n", hex(background))
+
+	if l.over {
+		fmt.Fprintf(out, `<rect class="overshoot" x="%.2f" y="%.2f" width="%.2f" height="%.2f" fill="%s"/>`+"\n",
+			l.left, l.top, l.right-l.left, l.y(l.high)-l.top, hex(overshoot))
+	}
+	if l.under {
+		fmt.Fprintf(out, `<rect class="overshoot" x="%.2f" y="%.2f" width="%.2f" height="%.2f" fill="%s"/>`+"\n",
+			l.left, l.y(l.low), l.right-l.left, l.bottom-l.y(l.low), hex(overshoot))
+	}
+
+	for _, value := range ticks(l.minX, l.maxX, tickCount) {
+		x := l.x(value)
+		fmt.Fprintf(out, `<line x1="%.2f" y1="%.2f" x2="%.2f" y2="%.2f" stroke="%s"/>`+"\n", x, l.top, x, l.bottom, hex(grid))
+		fmt.Fprintf(out, `<text x="%.2f" y="%.2f" text-anchor="middle" fill="%s">%s</text>`+"\n", x, l.bottom+15, hex(foreground), label(value))
+	}
+	for _, value := range ticks(l.minY, l.maxY, tickCount) {
+		y := l.y(value)
+		fmt.Fprintf(out, `<line x1="%.2f" y1="%.2f" x2="%.2f" y2="%.2f" stroke="%s"/>`+"\n", l.left, y, l.right, y, hex(grid))
+		fmt.Fprintf(out, `<text x="%.2f" y="%.2f" text-anchor="end" dominant-baseline="middle" fill="%s">%s</text>`+"\n", l.left-6, y, hex(foreground), label(value))
+	}
+	for _, value := range []float64{l.low, l.high} {
+		y := l.y(value)
+		fmt.Fprintf(out, `<line x1="%.2f" y1="%.2f" x2="%.2f" y2="%.2f" stroke="%s" stroke-dasharray="4 3"/>`+"\n", l.left, y, l.right, y, hex(foreground))
+	}
+	fmt.Fprintf(out, `<path d="M%.2f %.2fV%.2fH%.2f" fill="none" stroke="%s"/>`+"\n", l.left, l.top, l.bottom, l.right, hex(foreground))
+
+	for i, curve := range plot.Curves {
+		points := make([]string, 0, len(curve.Points))
+		for _, point := range curve.Points {
+			if math.IsNaN(point.Y) || math.IsInf(point.Y, 0) {
+				continue
+			}
+			points = append(points, fmt.Sprintf("%.2f,%.2f", l.x(point.X), l.y(point.Y)))
+		}
+		fmt.Fprintf(out, `<polyline class="curve" points="%s" fill="none" stroke="%s" stroke-width="2"/>`+"\n", strings.Join(points, " "), hex(colorOf(i)))
+	}
+
+	for i, curve := range plot.Curves {
+		y := l.top + 12 + float64(i)*14
+		fmt.Fprintf(out, `<line x1="%.2f" y1="%.2f" x2="%.2f" y2="%.2f" stroke="%s" stroke-width="2"/>`+"\n", l.right-110, y, l.right-95, y, hex(colorOf(i)))
+		fmt.Fprintf(out, `<text x="%.2f" y="%.2f" dominant-baseline="middle" fill="%s">%s</text>`+"\n", l.right-90, y, hex(foreground), escape(curve.Name))
+	}
+	if plot.Title != "" {
+		fmt.Fprintf(out, `<text x="%.2f" y="%d" text-anchor="middle" font-size="14" fill="%s">%s</text>`+"\n", float64(plot.Width)/2, marginTop-10, hex(foreground), escape(plot.Title))
+	}
+	fmt.Fprintln(out, `</svg>`)
+	return out.Flush()
+}
+
+// hex returns the color as a hex triplet.
+func hex(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+// escape escapes text for use within an XML element.
+func escape(text string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(text))
+	return b.String()
+}
@@ -0,0 +1,146 @@
+package plot
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+)
+
+// canvas is a grid of dots that the curves of a Plot are drawn on for text
+// output.
+type canvas struct {
+	width, height int
+	dots          []bool
+}
+
+func newCanvas(width, height int) *canvas {
+	return &canvas{width: width, height: height, dots: make([]bool, width*height)}
+}
+
+func (c *canvas) set(x, y int) {
+	if x >= 0 && x < c.width && y >= 0 && y < c.height {
+		c.dots[y*c.width+x] = true
+	}
+}
+
+func (c *canvas) get(x, y int) bool {
+	return c.dots[y*c.width+x]
+}
+
+// line sets every dot between the two points.
+func (c *canvas) line(x0, y0, x1, y1 float64) {
+	steps := int(math.Ceil(math.Max(math.Abs(x1-x0), math.Abs(y1-y0))))
+	for i := 0; i <= steps; i++ {
+		t := 0.0
+		if steps > 0 {
+			t = float64(i) / float64(steps)
+		}
+		c.set(int(math.Round(x0+(x1-x0)*t)), int(math.Round(y0+(y1-y0)*t)))
+	}
+}
+
+// Braille writes the Plot to w as text that is columns characters wide and rows
+// characters high, not counting the axis labels. Each character is a Unicode
+// braille pattern of 2 by 4 dots, giving a far higher resolution than ASCII. The
+// values the curves animate between are marked with dotted lines.
+func (plot *Plot) Braille(w io.Writer, columns, rows int) error {
+	return plot.text(w, columns, rows, 2, 4, func(c *canvas, column, row int) rune {
+		// braille dots are numbered down the left column then the right, with the
+		// bottom row of each column added last
+		offsets := [4][2]uint{{0, 3}, {1, 4}, {2, 5}, {6, 7}}
+		var bits rune
+		for y := 0; y < 4; y++ {
+			for x := 0; x < 2; x++ {
+				if c.get(column*2+x, row*4+y) {
+					bits |= 1 << offsets[y][x]
+				}
+			}
+		}
+		if bits == 0 {
+			return ' '
+		}
+		return 0x2800 + bits
+	})
+}
+
+// ASCII writes the Plot to w as plain ASCII text that is columns characters wide
+// and rows characters high, not counting the axis labels, for terminals without
+// Unicode support.
+func (plot *Plot) ASCII(w io.Writer, columns, rows int) error {
+	return plot.text(w, columns, rows, 1, 1, func(c *canvas, column, row int) rune {
+		if c.get(column, row) {
+			return '*'
+		}
+		return ' '
+	})
+}
+
+// text draws the Plot on a canvas with dotsX by dotsY dots per character and
+// writes it to w with axes, using char to pick the character for each cell.
+func (plot *Plot) text(w io.Writer, columns, rows, dotsX, dotsY int, char func(c *canvas, column, row int) rune) error {
+	if columns < 2 || rows < 2 {
+		return fmt.Errorf("plot: %dx%d is too small to plot", columns, rows)
+	}
+	b := plot.bounds()
+	c := newCanvas(columns*dotsX, rows*dotsY)
+	l := layout{
+		bounds: b,
+		right:  float64(c.width - 1),
+		bottom: float64(c.height - 1),
+	}
+
+	for _, value := range []float64{b.low, b.high} {
+		y := l.y(value)
+		for x := 0; x < c.width; x += 2 * dotsX {
+			c.set(x, int(math.Round(y)))
+		}
+	}
+	for _, curve := range plot.Curves {
+		var previous *Point
+		for i := range curve.Points {
+			point := curve.Points[i]
+			if math.IsNaN(point.Y) || math.IsInf(point.Y, 0) {
+				continue
+			}
+			if previous != nil {
+				c.line(l.x(previous.X), l.y(previous.Y), l.x(point.X), l.y(point.Y))
+			}
+			previous = &curve.Points[i]
+		}
+	}
+
+	top, bottom := label(b.maxY), label(b.minY)
+	margin := len(top)
+	if len(bottom) > margin {
+		margin = len(bottom)
+	}
+
+	out := bufio.NewWriter(w)
+	if plot.Title != "" {
+		fmt.Fprintf(out, "%*s%s\n", margin+1, "", plot.Title)
+	}
+	for row := 0; row < rows; row++ {
+		tick := ""
+		switch row {
+		case 0:
+			tick = top
+		case rows - 1:
+			tick = bottom
+		}
+		fmt.Fprintf(out, "%*s|", margin, tick)
+		for column := 0; column < columns; column++ {
+			out.WriteRune(char(c, column, row))
+		}
+		out.WriteByte('\n')
+	}
+	fmt.Fprintf(out, "%*s+%s\n", margin, "", strings.Repeat("-", columns))
+	left, right := label(b.minX), label(b.maxX)
+	gap := columns - len(left) - len(right)
+	if gap < 1 {
+		gap = 1
+	}
+	fmt.Fprintf(out, "%*s%s%*s%s\n", margin+1, "", left, gap, "", right)
+	return out.Flush()
+}
@@ -0,0 +1,65 @@
+package gween
+
+import (
+	"sync"
+
+	"github.com/tanema/gween/ease"
+)
+
+var (
+	tweenPool    = sync.Pool{New: func() interface{} { return &Tween{} }}
+	sequencePool = sync.Pool{New: func() interface{} { return &Sequence{} }}
+)
+
+// Acquire returns a Tween from the pool, set up exactly as if it had been created
+// with New. Tweens that are created and discarded often, such as those of hit
+// effects, can be acquired and released to avoid creating garbage every frame.
+func Acquire(begin, end, duration float32, easing ease.TweenFunc) *Tween {
+	tween := tweenPool.Get().(*Tween)
+	tween.setup(begin, end, duration, easing)
+	return tween
+}
+
+// Release resets every field of the Tween and returns it to the pool. The Tween
+// must not be used again after it has been released.
+func Release(tween *Tween) {
+	if tween == nil {
+		return
+	}
+	tween.setup(0, 0, 0, nil)
+	tweenPool.Put(tween)
+}
+
+// AcquireSequence returns a Sequence from the pool containing the provided Tweens,
+// set up exactly as if it had been created with NewSequence. The memory of the
+// Tweens slice of a released Sequence is reused.
+func AcquireSequence(tweens ...*Tween) *Sequence {
+	seq := sequencePool.Get().(*Sequence)
+	seq.setup(nil)
+	seq.Tweens = append(seq.Tweens, tweens...)
+	return seq
+}
+
+// ReleaseSequence releases every Tween in the Sequence, resets every field of the
+// Sequence and returns it to the pool. Neither the Sequence nor its Tweens may be
+// used again after they have been released.
+func ReleaseSequence(seq *Sequence) {
+	if seq == nil {
+		return
+	}
+	for _, tween := range seq.Tweens {
+		Release(tween)
+	}
+	seq.setup(nil)
+	sequencePool.Put(seq)
+}
+
+// release returns a Tween or Sequence to its pool.
+func release(animation Animation) {
+	switch animation := animation.(type) {
+	case *Tween:
+		Release(animation)
+	case *Sequence:
+		ReleaseSequence(animation)
+	}
+}
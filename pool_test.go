@@ -0,0 +1,95 @@
+package gween
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tanema/gween/ease"
+)
+
+func TestAcquire(t *testing.T) {
+	tween := Acquire(0, 10, 10, ease.Linear)
+	assert.Equal(t, New(0, 10, 10, ease.Linear).Value(), tween.Value())
+	current, isFinished := tween.Update(5)
+	assert.Equal(t, float32(5), current)
+	assert.False(t, isFinished)
+}
+
+func TestRelease(t *testing.T) {
+	tween := NewBy(5, 10, ease.Linear)
+	tween.SetID("hit")
+	tween.Tag("fx")
+	tween.SetSource(func() float32 { return 3 })
+	tween.SetTimeScale(2)
+	tween.SetLoop(3)
+	tween.SetLoopMode(LoopYoyo)
+	tween.Pause()
+	tween.Update(2)
+	Release(tween)
+
+	assert.Equal(t, Tween{timeScale: 1, resolved: true, loop: 1, loopRemaining: 1, labels: labels{tags: tween.tags}}, *tween)
+	assert.Len(t, tween.Tags(), 0)
+
+	tween.setup(1, 2, 3, ease.Linear)
+	tween.easing = nil
+	assert.Equal(t, Tween{begin: 1, end: 2, change: 1, duration: 3, timeScale: 1, resolved: true, loop: 1, loopRemaining: 1, labels: labels{tags: tween.tags}}, *tween)
+}
+
+func TestReleaseSequence(t *testing.T) {
+	first, second := New(0, 1, 1, ease.Linear), New(1, 2, 1, ease.Linear)
+	seq := NewSequence(first, second)
+	seq.SetID("combo")
+	seq.SetLoop(3)
+	seq.SetYoyo(true)
+	seq.SetOnTweenComplete(func(int) {})
+	seq.Update(1.5)
+	tweens := seq.Tweens
+	ReleaseSequence(seq)
+
+	assert.Len(t, seq.Tweens, 0)
+	assert.Equal(t, []*Tween{nil, nil}, tweens)
+	assert.Equal(t, "", seq.ID())
+	assert.Equal(t, 1, seq.Loop())
+	assert.False(t, seq.Yoyo())
+	assert.Nil(t, seq.onTweenComplete)
+	assert.Equal(t, float32(0), first.duration)
+	assert.Equal(t, float32(0), second.duration)
+
+	seq.setup([]*Tween{New(0, 1, 1, ease.Linear)})
+	assert.Equal(t, &tweens[0], &seq.Tweens[0])
+	current, _, _ := seq.Update(0.5)
+	assert.Equal(t, float32(0.5), current)
+}
+
+func TestGroup_AutoRelease(t *testing.T) {
+	short, long := Acquire(0, 1, 1, ease.Linear), Acquire(0, 1, 2, ease.Linear)
+	group := NewGroup(short, long)
+	group.SetAutoRelease(true)
+	assert.True(t, group.AutoRelease())
+	group.Update(1)
+	assert.Equal(t, []Animation{long}, group.Animations)
+	assert.Equal(t, float32(0), short.duration)
+	assert.Equal(t, float32(2), long.duration)
+}
+
+func TestManager_AutoRelease(t *testing.T) {
+	seq := AcquireSequence(Acquire(0, 1, 1, ease.Linear))
+	manager := NewManager(seq)
+	manager.SetAutoRelease(true)
+	assert.True(t, manager.Update(1))
+	assert.Len(t, seq.Tweens, 0)
+}
+
+func TestAcquire_Allocs(t *testing.T) {
+	if raceEnabled {
+		t.Skip("the race detector randomly drops pooled items")
+	}
+	group := NewGroup()
+	group.SetAutoRelease(true)
+	allocs := testing.AllocsPerRun(100, func() {
+		group.Add(AcquireSequence(Acquire(0, 1, 1, ease.Linear), Acquire(1, 0, 1, ease.Linear)))
+		group.Update(2)
+	})
+	assert.Equal(t, float64(0), allocs)
+}
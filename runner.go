@@ -0,0 +1,124 @@
+package gween
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Ticker delivers the ticks that drive a Runner. It matches the behaviour of a
+// time.Ticker so that a fake can be provided in tests.
+type Ticker interface {
+	// C returns the channel on which the time of each tick is delivered.
+	C() <-chan time.Time
+	// Stop turns off the ticker.
+	Stop()
+}
+
+// timeTicker adapts a time.Ticker to the Ticker interface.
+type timeTicker struct {
+	*time.Ticker
+}
+
+func (ticker timeTicker) C() <-chan time.Time {
+	return ticker.Ticker.C
+}
+
+// Runner advances a Tween or Sequence on its own goroutine, for uses without a
+// game loop such as terminal UIs or LED controllers. On every tick the animation
+// is advanced by the time elapsed since the previous tick, and its value is sent
+// on the Values channel. A Runner can only be run once.
+type Runner struct {
+	animation Animation
+	newTicker func() Ticker
+	now       func() time.Time
+	values    chan float32
+	done      chan struct{}
+	once      sync.Once
+	err       error
+}
+
+// NewRunner returns a new Runner advancing the animation every interval, which
+// must be positive.
+func NewRunner(animation Animation, interval time.Duration) *Runner {
+	return NewRunnerWithTicker(animation, func() Ticker {
+		return timeTicker{time.NewTicker(interval)}
+	}, time.Now)
+}
+
+// NewRunnerWithTicker returns a new Runner driven by the ticker returned from
+// newTicker when it starts running. The time elapsed before the first tick is
+// measured from the time returned by now when the Runner starts.
+func NewRunnerWithTicker(animation Animation, newTicker func() Ticker, now func() time.Time) *Runner {
+	return &Runner{
+		animation: animation,
+		newTicker: newTicker,
+		now:       now,
+		values:    make(chan float32),
+		done:      make(chan struct{}),
+	}
+}
+
+// Values returns the channel on which the value of the animation is sent after
+// every tick. It is closed once the Runner stops.
+func (runner *Runner) Values() <-chan float32 {
+	return runner.values
+}
+
+// Done returns a channel that is closed once the Runner stops, either because the
+// animation completed or because its context was canceled.
+func (runner *Runner) Done() <-chan struct{} {
+	return runner.done
+}
+
+// Err waits for the Runner to stop and returns nil if the animation ran to
+// completion, or the error of the context if it was canceled first.
+func (runner *Runner) Err() error {
+	<-runner.done
+	return runner.err
+}
+
+// Start runs the Runner on a new goroutine.
+func (runner *Runner) Start(ctx context.Context) {
+	go runner.Run(ctx)
+}
+
+// Run advances the animation on every tick until it completes or ctx is
+// canceled, blocking until then. Values must be received for the Runner to make
+// progress. It returns nil if the animation completed, or the error of ctx.
+func (runner *Runner) Run(ctx context.Context) error {
+	ran := false
+	runner.once.Do(func() {
+		ran = true
+		runner.err = runner.run(ctx)
+		close(runner.values)
+		close(runner.done)
+	})
+	if !ran {
+		return runner.Err()
+	}
+	return runner.err
+}
+
+func (runner *Runner) run(ctx context.Context) error {
+	ticker := runner.newTicker()
+	defer ticker.Stop()
+	last := runner.now()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case now := <-ticker.C():
+			isFinished := runner.animation.step(seconds(now.Sub(last)))
+			last = now
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case runner.values <- runner.animation.Value():
+			}
+			if isFinished {
+				return nil
+			}
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package gween
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tanema/gween/ease"
+)
+
+type fakeTicker struct {
+	c       chan time.Time
+	stopped chan struct{}
+}
+
+func newFakeTicker() *fakeTicker {
+	return &fakeTicker{c: make(chan time.Time), stopped: make(chan struct{})}
+}
+
+func (ticker *fakeTicker) C() <-chan time.Time {
+	return ticker.c
+}
+
+func (ticker *fakeTicker) Stop() {
+	close(ticker.stopped)
+}
+
+func newFakeRunner(animation Animation) (*Runner, *fakeTicker, time.Time) {
+	ticker := newFakeTicker()
+	start := time.Unix(100, 0)
+	runner := NewRunnerWithTicker(animation, func() Ticker { return ticker }, func() time.Time { return start })
+	return runner, ticker, start
+}
+
+func TestRunner(t *testing.T) {
+	seq := NewSequence(New(0, 1, 1, ease.Linear), New(1, 3, 1, ease.Linear))
+	runner, ticker, start := newFakeRunner(seq)
+	runner.Start(context.Background())
+
+	ticker.c <- start.Add(500 * time.Millisecond)
+	assert.Equal(t, float32(0.5), <-runner.Values())
+	ticker.c <- start.Add(1500 * time.Millisecond)
+	assert.Equal(t, float32(2), <-runner.Values())
+	ticker.c <- start.Add(2500 * time.Millisecond)
+	assert.Equal(t, float32(3), <-runner.Values())
+
+	<-runner.Done()
+	<-ticker.stopped
+	_, open := <-runner.Values()
+	assert.False(t, open)
+	assert.NoError(t, runner.Err())
+	assert.NoError(t, runner.Run(context.Background()))
+}
+
+func TestRunner_Cancel(t *testing.T) {
+	tween := New(0, 10, 10, ease.Linear)
+	runner, ticker, start := newFakeRunner(tween)
+	ctx, cancel := context.WithCancel(context.Background())
+	runner.Start(ctx)
+
+	ticker.c <- start.Add(time.Second)
+	assert.Equal(t, float32(1), <-runner.Values())
+	cancel()
+	<-runner.Done()
+	<-ticker.stopped
+	assert.Equal(t, context.Canceled, runner.Err())
+	assert.Equal(t, float32(1), tween.Value())
+}
+
+func TestRunner_CancelWhileSending(t *testing.T) {
+	runner, ticker, start := newFakeRunner(New(0, 10, 10, ease.Linear))
+	ctx, cancel := context.WithCancel(context.Background())
+	runner.Start(ctx)
+
+	// Nobody receives the value, cancelling must still stop the runner
+	ticker.c <- start.Add(time.Second)
+	cancel()
+	assert.Equal(t, context.Canceled, runner.Err())
+}
+
+func TestRunner_RealTicker(t *testing.T) {
+	runner := NewRunner(New(0, 1, 0.02, ease.Linear), time.Millisecond)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	runner.Start(ctx)
+	var last float32
+	for value := range runner.Values() {
+		last = value
+	}
+	assert.NoError(t, runner.Err())
+	assert.Equal(t, float32(1), last)
+}
@@ -1,5 +1,7 @@
 package gween
 
+import "math/rand"
+
 // Sequence represents a sequence of Tweens, executed one after the other.
 type Sequence struct {
 	Tweens []*Tween
@@ -12,30 +14,158 @@ type Sequence struct {
 	loop int
 	// loopRemaining is the remaining number of times to loop through the sequence
 	loopRemaining int
+	// loopMode is how each loop after the first is played
+	loopMode LoopMode
+	// yoyoLoops is how yoyo loops are counted
+	yoyoLoops YoyoLoops
+	// completedLoops is the number of loops completed, not counting the last
+	completedLoops int
+	// turned is whether SetReverse turned a yoyo sequence around since it last
+	// left an end
+	turned bool
+	// shift is how far incremental loops have moved the values of the sequence
+	shift float32
+	// rand shuffles the tweens of LoopShuffle sequences
+	rand *rand.Rand
+	// order is the position each tween had before LoopShuffle reordered them, nil
+	// if they have not been reordered since tweens were last added or removed
+	order []int
+	// paused stops Update from advancing the sequence
+	paused bool
+	// timeScale multiplies every dt passed to Update
+	timeScale float32
+	// onTweenComplete is called with the index of every Tween completed by Update
+	onTweenComplete func(index int)
+	// markers are ordered by time
+	markers  []Marker
+	onMarker func(marker Marker, direction Direction)
+	// atStart is whether the sequence has not moved since it started a loop, so
+	// markers where the loop starts are passed when it moves
+	atStart bool
+	// dispatch, when set, is passed every callback to call later instead of
+	// calling it straight away
+	dispatch func(fn func())
+	labels
 }
 
 // NewSequence returns a new Sequence object.
 func NewSequence(tweens ...*Tween) *Sequence {
-	seq := &Sequence{
+	seq := &Sequence{}
+	seq.setup(tweens)
+	return seq
+}
+
+// setup sets every field of the Sequence to that of a new Sequence, reusing the
+// memory of its Tweens slice and tags.
+func (seq *Sequence) setup(tweens []*Tween) {
+	if seq.Tweens != nil {
+		for i := range seq.Tweens {
+			seq.Tweens[i] = nil
+		}
+		tweens = append(seq.Tweens[:0], tweens...)
+	}
+	*seq = Sequence{
 		Tweens:        tweens,
 		yoyo:          false,
 		reverse:       false,
 		loopRemaining: 1,
 		loop:          1,
+		timeScale:     1,
+		markers:       seq.markers[:0],
+		atStart:       true,
+		labels:        labels{tags: seq.tags[:0]},
 	}
-	return seq
 }
 
 // Add adds one or more Tweens in order to the Sequence.
 func (seq *Sequence) Add(tweens ...*Tween) {
 	seq.Tweens = append(seq.Tweens, tweens...)
+	seq.order = nil
+}
+
+// Insert inserts one or more Tweens in order at index, which must be between 0
+// and the number of Tweens. The playhead stays on the current Tween, so Tweens
+// inserted before it are not played until the next loop. Tweens inserted at the
+// current index are played next if the current Tween has not started yet. In
+// reverse the last of them is played first, and the current Tween, which is then
+// behind them, is not played until the next loop.
+func (seq *Sequence) Insert(index int, tweens ...*Tween) {
+	if index < 0 || index > len(seq.Tweens) || len(tweens) == 0 {
+		return
+	}
+	shift := index < seq.index || index == seq.index && seq.started()
+	seq.Tweens = append(seq.Tweens, tweens...)
+	copy(seq.Tweens[index+len(tweens):], seq.Tweens[index:])
+	copy(seq.Tweens[index:], tweens)
+	seq.order = nil
+	switch {
+	case shift:
+		seq.index += len(tweens)
+	case index == seq.index && seq.reverse:
+		// Played backward, the last of the inserted Tweens comes first
+		seq.index += len(tweens) - 1
+		seq.enter(seq.index)
+	case index == seq.index:
+		seq.enter(index)
+	}
+}
+
+// Replace replaces the Tween at index with tween. If it replaces the current
+// Tween, tween starts playing from its beginning.
+func (seq *Sequence) Replace(index int, tween *Tween) {
+	if index < 0 || index >= len(seq.Tweens) {
+		return
+	}
+	seq.Tweens[index] = tween
+	if index == seq.index {
+		seq.enter(index)
+	}
 }
 
-// Remove removes a Tween of the specified index from the Sequence.
+// Remove removes a Tween of the specified index from the Sequence. The playhead
+// stays on the current Tween when a Tween before it is removed. When the current
+// Tween is removed the next Tween in the direction of play starts from its
+// beginning.
 func (seq *Sequence) Remove(index int) {
-	if index >= 0 && index < len(seq.Tweens) {
-		seq.Tweens = append(seq.Tweens[:index], seq.Tweens[index+1:]...)
+	if index < 0 || index >= len(seq.Tweens) {
+		return
+	}
+	copy(seq.Tweens[index:], seq.Tweens[index+1:])
+	seq.Tweens[len(seq.Tweens)-1] = nil
+	seq.Tweens = seq.Tweens[:len(seq.Tweens)-1]
+	seq.order = nil
+	switch {
+	case index < seq.index:
+		seq.index--
+	case index == seq.index:
+		if seq.reverse {
+			seq.index--
+		}
+		seq.enter(seq.index)
+	}
+}
+
+// Clear removes every Tween from the Sequence and resets it, so that Tweens
+// added afterwards play forward from the beginning with every loop left to play,
+// even if the Sequence had finished.
+func (seq *Sequence) Clear() {
+	for i := range seq.Tweens {
+		seq.Tweens[i] = nil
 	}
+	seq.Tweens = seq.Tweens[:0]
+	seq.order = nil
+	seq.reverse = false
+	seq.Reset()
+}
+
+// IndexOf returns the index of tween in the Sequence, or -1 if it is not in it.
+func (seq *Sequence) IndexOf(tween *Tween) int {
+	for i, t := range seq.Tweens {
+		if t == tween {
+			return i
+		}
+	}
+	return -1
 }
 
 // Update updates the currently active Tween in the Sequence; once that Tween is done, the Sequence moves onto the next one.
@@ -45,67 +175,186 @@ func (seq *Sequence) Update(dt float32) (value float32, tweenComplete, sequenceC
 	if !seq.HasTweens() {
 		return 0, false, true
 	}
-	var completed []int
-	remaining := dt
+	if seq.paused {
+		return seq.Value(), false, seq.finished()
+	}
+	return seq.advance(dt * seq.timeScale)
+}
+
+// Seek sets the Sequence to the state it would be in after being Reset and then
+// played for time, ignoring whether it is paused or time scaled.
+func (seq *Sequence) Seek(time float32) {
+	if seq.yoyo {
+		// Yoyo sequences always start forward, whichever way they are heading now
+		seq.reverse = false
+		for _, tween := range seq.Tweens {
+			tween.rewind(false)
+		}
+	}
+	seq.Reset()
+	if seq.HasTweens() {
+		seq.advance(time)
+	}
+}
 
+// advance moves the Sequence forward by remaining, carrying the overflow of each
+// completed Tween into the next.
+func (seq *Sequence) advance(remaining float32) (value float32, tweenComplete, sequenceComplete bool) {
+	value, tweenComplete, sequenceComplete = seq.play(remaining)
+	return value + seq.shift, tweenComplete, sequenceComplete
+}
+
+// play is advance without the shift of incremental loops.
+func (seq *Sequence) play(remaining float32) (value float32, tweenComplete, sequenceComplete bool) {
+	if seq.finished() {
+		return seq.Tweens[seq.clampIndex(seq.index)].Value(), false, true
+	}
+	// instant counts the Tweens completed in a row without consuming any time
+	instant := 0
+	// bounced is whether the current Tween is being replayed after a yoyo bounce
+	bounced := false
 	for {
 		if seq.yoyo {
 			if seq.index < 0 {
+				if seq.spinning(instant) {
+					return seq.Tweens[0].begin, tweenComplete, false
+				}
 				// Out of bounds at beginnning, loop
+				counts := seq.countsLoop()
+				if counts && seq.waiting(remaining) {
+					return seq.Tweens[0].begin, tweenComplete, false
+				}
 				seq.reverse = false
+				seq.turned = false
 				seq.index = seq.clampIndex(seq.index)
-				if seq.loopRemaining >= 1 {
-					seq.loopRemaining--
-				}
-				if seq.loopRemaining == 0 || remaining == 0 {
-					return seq.Tweens[seq.index].begin, len(completed) > 0, true
+				if counts && seq.consumeLoop() {
+					return seq.Tweens[seq.index].begin, tweenComplete, true
 				}
-				seq.Tweens[seq.index].reverse = seq.Reverse()
-				seq.Tweens[seq.index].Reset()
+				seq.enter(seq.index)
+				bounced = true
 			}
 			if seq.index >= len(seq.Tweens) {
+				last := seq.Tweens[len(seq.Tweens)-1]
+				// Half cycles also count a loop at the end
+				if seq.yoyoLoops.HalfCycles && seq.countsLoop() {
+					if seq.waiting(remaining) {
+						return last.end, tweenComplete, false
+					}
+					if seq.consumeLoop() {
+						return last.end, tweenComplete, true
+					}
+				}
 				// Out of bounds at end, yoyo
 				seq.reverse = true
+				seq.turned = false
 				seq.index = seq.clampIndex(seq.index)
 
-				seq.Tweens[seq.index].reverse = seq.Reverse()
-				seq.Tweens[seq.index].Reset()
+				seq.enter(seq.index)
+				bounced = true
 			}
 		} else if seq.index >= len(seq.Tweens) || seq.index <= -1 {
+			if seq.spinning(instant) {
+				if seq.reverse {
+					return seq.Tweens[seq.clampIndex(seq.index)].begin, tweenComplete, false
+				}
+				return seq.Tweens[seq.clampIndex(seq.index)].end, tweenComplete, false
+			}
 			// out of bounds at either end, loop
-			if seq.loopRemaining >= 1 {
-				seq.loopRemaining--
+			if seq.waiting(remaining) {
+				if seq.reverse {
+					return seq.Tweens[seq.clampIndex(seq.index)].begin, tweenComplete, false
+				}
+				return seq.Tweens[seq.clampIndex(seq.index)].end, tweenComplete, false
 			}
-			if seq.loopRemaining == 0 || remaining == 0 {
+			if seq.consumeLoop() {
 				if seq.reverse {
-					return seq.Tweens[seq.clampIndex(seq.index)].begin, len(completed) > 0, true
+					return seq.Tweens[seq.clampIndex(seq.index)].begin, tweenComplete, true
 				}
-				return seq.Tweens[seq.clampIndex(seq.index)].end, len(completed) > 0, true
+				return seq.Tweens[seq.clampIndex(seq.index)].end, tweenComplete, true
+			}
+			switch seq.loopMode {
+			case LoopIncremental:
+				seq.increment(1)
+			case LoopShuffle:
+				seq.shuffle()
 			}
 			seq.index = seq.wrapIndex(seq.index)
-			seq.Tweens[seq.index].reverse = seq.Reverse()
-			seq.Tweens[seq.index].Reset()
+			seq.enter(seq.index)
+			seq.atStart = true
 		}
+		seq.resolve(seq.index)
+		from := seq.Tweens[seq.index].time
 		v, tc := seq.Tweens[seq.index].Update(remaining)
+		seq.fireMarkers(seq.index, from, seq.Tweens[seq.index].time)
 		if !tc {
-			return v, len(completed) > 0, false
+			return v, tweenComplete, false
+		}
+		// Callbacks were already passed on the way into a bounce
+		if callback := seq.Tweens[seq.index].callback; callback != nil && !bounced {
+			seq.call(callback)
 		}
+		bounced = false
+		previous := remaining
 		remaining = seq.Tweens[seq.index].Overflow
-		completed = append(completed, seq.index)
+		tweenComplete = true
+		if seq.onTweenComplete != nil {
+			seq.tweenCompleted(seq.index)
+		}
 		if remaining < 0 {
 			remaining *= -1
 		}
+		// Overflow is in the tween's own time, bring it back to the sequence's
+		if scale := seq.Tweens[seq.index].timeScale; scale != 0 {
+			remaining /= scale
+		}
+		if remaining < previous {
+			instant = 0
+		} else {
+			instant++
+		}
 		if seq.reverse {
 			seq.index--
 		} else {
 			seq.index++
 		}
 		// On the way back, tweens need to be configured to not go forward
-		if seq.index < len(seq.Tweens) && seq.index >= 0 {
-			seq.Tweens[seq.index].reverse = seq.Reverse()
-			seq.Tweens[seq.index].Reset()
-		}
+		seq.enter(seq.index)
+	}
+}
+
+// Value returns the current value of the Sequence without advancing it.
+func (seq *Sequence) Value() float32 {
+	if !seq.HasTweens() {
+		return 0
+	}
+	return seq.Tweens[seq.clampIndex(seq.index)].Value() + seq.shift
+}
+
+// Velocity returns the rate at which the value of the Sequence is changing, per
+// unit of the dt passed to Update. It is 0 while the Sequence is paused or
+// finished.
+func (seq *Sequence) Velocity() float32 {
+	if seq.paused || seq.finished() {
+		return 0
+	}
+	return seq.Tweens[seq.clampIndex(seq.index)].Velocity() * seq.timeScale
+}
+
+// Acceleration returns the rate at which the Velocity of the Sequence is
+// changing, per unit of the dt passed to Update. It is 0 while the Sequence is
+// paused or finished.
+func (seq *Sequence) Acceleration() float32 {
+	if seq.paused || seq.finished() {
+		return 0
 	}
+	return seq.Tweens[seq.clampIndex(seq.index)].Acceleration() * seq.timeScale * seq.timeScale
+}
+
+// SetOnTweenComplete sets a function that is called with the index of every Tween
+// that completes during Update, in the order they complete. A single Update can
+// complete several Tweens, or the same Tween several times when looping.
+func (seq *Sequence) SetOnTweenComplete(fn func(index int)) {
+	seq.onTweenComplete = fn
 }
 
 // Index returns the current index of the Sequence. Note that this can exceed the number of Tweens in the Sequence.
@@ -115,8 +364,7 @@ func (seq *Sequence) Index() int {
 
 // SetIndex sets the current index of the Sequence, influencing which Tween is active at any given time.
 func (seq *Sequence) SetIndex(index int) {
-	seq.Tweens[seq.index].reverse = seq.Reverse()
-	seq.Tweens[seq.index].Reset()
+	seq.enter(seq.index)
 	seq.index = index
 }
 
@@ -124,11 +372,28 @@ func (seq *Sequence) SetIndex(index int) {
 func (seq *Sequence) SetLoop(amount int) {
 	seq.loop = amount
 	seq.loopRemaining = seq.loop
+	seq.completedLoops = 0
+}
+
+// Loop returns the number of loops the Sequence was set to make with SetLoop.
+func (seq *Sequence) Loop() int {
+	return seq.loop
+}
+
+// Yoyo returns whether the Sequence yoyos back to the beginning after it reaches
+// the end.
+func (seq *Sequence) Yoyo() bool {
+	return seq.yoyo
 }
 
 // SetYoyo sets whether the Sequence should yoyo off of the end of the last Tween and complete at the beginning of the first Tween
 func (seq *Sequence) SetYoyo(willYoyo bool) {
-	seq.yoyo = willYoyo
+	switch {
+	case willYoyo && !seq.yoyo:
+		seq.SetLoopMode(LoopYoyo)
+	case !willYoyo && seq.yoyo:
+		seq.SetLoopMode(LoopRestart)
+	}
 }
 
 // Reset resets the Sequence, resetting all Tweens and setting the Sequence's index back to 0.
@@ -138,6 +403,10 @@ func (seq *Sequence) Reset() {
 		tween.Reset()
 	}
 	seq.index = 0
+	seq.shift = 0
+	seq.completedLoops = 0
+	seq.turned = false
+	seq.atStart = true
 }
 
 // HasTweens returns whether the Sequence is populated with Tweens or not.
@@ -150,15 +419,172 @@ func (seq *Sequence) Reverse() bool {
 	return seq.reverse
 }
 
-// SetReverse sets whether the Sequence will start running in reverse.
+// SetReverse sets whether the Sequence will start running in reverse. Unlike
+// PlayForward and PlayBackward, it does not play a finished Sequence again.
 func (seq *Sequence) SetReverse(r bool) {
+	if !seq.HasTweens() {
+		seq.reverse = r
+		return
+	}
 	if seq.index >= len(seq.Tweens) || seq.index < 0 {
 		seq.index = seq.clampIndex(seq.index)
 	}
+	if seq.yoyo && r != seq.reverse {
+		seq.turned = true
+	}
 	seq.Tweens[seq.index].reverse = r
 	seq.reverse = r
 }
 
+// Complete jumps the Sequence to its final state, consuming all remaining loops,
+// as if it had been updated to completion.
+func (seq *Sequence) Complete() {
+	if !seq.HasTweens() {
+		return
+	}
+	if seq.loopMode == LoopIncremental && seq.loopRemaining > 1 {
+		seq.increment(seq.loopRemaining - 1)
+	}
+	atEnd := !seq.reverse && !seq.yoyo
+	if seq.yoyo && seq.yoyoLoops.HalfCycles {
+		switch {
+		case seq.loopRemaining > 0:
+			// Every other half cycle finishes at the end
+			atEnd = (seq.loopRemaining%2 == 1) != seq.reverse
+		case seq.loopRemaining == 0:
+			atEnd = seq.index >= len(seq.Tweens)
+		}
+	}
+	if seq.loop > 0 {
+		seq.completedLoops = seq.loop - 1
+	}
+	seq.loopRemaining = 0
+	seq.turned = false
+	if atEnd {
+		seq.reverse = false
+		for _, tween := range seq.Tweens {
+			tween.rewind(false)
+			tween.Complete()
+		}
+		seq.index = len(seq.Tweens)
+		return
+	}
+	// Otherwise the sequence finishes at the beginning of the first tween
+	for _, tween := range seq.Tweens {
+		tween.rewind(true)
+		tween.Complete()
+	}
+	seq.reverse = true
+	seq.index = -1
+}
+
+// Pause stops Update from advancing the Sequence until Resume is called.
+func (seq *Sequence) Pause() {
+	seq.paused = true
+}
+
+// Resume allows a paused Sequence to advance again.
+func (seq *Sequence) Resume() {
+	seq.paused = false
+}
+
+// Paused returns whether the Sequence is currently paused.
+func (seq *Sequence) Paused() bool {
+	return seq.paused
+}
+
+// SetTimeScale sets the multiplier applied to every dt passed to Update. It is
+// applied on top of the time scale of each Tween in the Sequence. Defaults to 1.
+func (seq *Sequence) SetTimeScale(scale float32) {
+	seq.timeScale = scale
+}
+
+// TimeScale returns the multiplier applied to every dt passed to Update.
+func (seq *Sequence) TimeScale() float32 {
+	return seq.timeScale
+}
+
+// finished returns whether the Sequence has completed all of its loops.
+func (seq *Sequence) finished() bool {
+	return !seq.HasTweens() || seq.loopRemaining == 0
+}
+
+func (seq *Sequence) step(dt float32) bool {
+	_, _, sequenceComplete := seq.Update(dt)
+	return sequenceComplete
+}
+
+func (seq *Sequence) visit(fn func(Animation)) {
+	fn(seq)
+	for _, tween := range seq.Tweens {
+		tween.visit(fn)
+	}
+}
+
+// spinning returns whether an infinitely looping Sequence has completed instant
+// Tweens in a row through a whole loop without consuming any time, in which case
+// Update stops at the end of the loop rather than looping forever.
+func (seq *Sequence) spinning(instant int) bool {
+	return seq.loopRemaining < 0 && instant >= len(seq.Tweens)
+}
+
+// enter sets the Tween at index, if there is one, to start playing from its
+// beginning in the direction of the Sequence. On the way back of a ping-pong
+// Sequence the Tween plays its back easing.
+func (seq *Sequence) enter(index int) {
+	if index >= 0 && index < len(seq.Tweens) {
+		tween := seq.Tweens[index]
+		tween.rewind(seq.reverse)
+		tween.mirrored = seq.reverse && seq.loopMode == LoopPingPong
+	}
+}
+
+// call calls fn, or passes it to dispatch to be called later.
+func (seq *Sequence) call(fn func()) {
+	if seq.dispatch != nil {
+		seq.dispatch(fn)
+	} else {
+		fn()
+	}
+}
+
+// tweenCompleted calls the tween complete callback with index, like call.
+func (seq *Sequence) tweenCompleted(index int) {
+	if seq.dispatch == nil {
+		seq.onTweenComplete(index)
+		return
+	}
+	fn := seq.onTweenComplete
+	seq.dispatch(func() { fn(index) })
+}
+
+// started returns whether the current Tween has moved from its beginning.
+func (seq *Sequence) started() bool {
+	if seq.index < 0 || seq.index >= len(seq.Tweens) {
+		return false
+	}
+	tween := seq.Tweens[seq.index]
+	if seq.reverse {
+		return tween.time < tween.duration
+	}
+	return tween.time > 0
+}
+
+// resolve resolves the relative Tween at index, and any unresolved Tweens before
+// it, against the end value of the Tween before each of them.
+func (seq *Sequence) resolve(index int) {
+	tween := seq.Tweens[index]
+	if tween.resolved {
+		return
+	}
+	if index == 0 {
+		tween.resolveSource()
+		return
+	}
+	seq.resolve(index - 1)
+	tween.Resolve(seq.Tweens[index-1].end)
+}
+
 // clampIndex clamps the provided index to the bounds of the Tweens slice
 func (seq *Sequence) clampIndex(index int) int {
 	if index >= len(seq.Tweens) {
@@ -323,12 +323,20 @@ func TestSequence_Remove(t *testing.T) {
 	assert.True(t, finishedTween)
 	assert.False(t, seqFinished)
 	assert.Equal(t, 2, seq.index)
+	// Removing tweens before the playhead keeps it on the current tween
 	seq.Remove(0)
 	assert.Equal(t, 3, len(seq.Tweens))
+	assert.Equal(t, 1, seq.index)
+	assert.Equal(t, float32(3.5), seq.Value())
 	seq.Remove(0)
 	assert.Equal(t, 2, len(seq.Tweens))
+	assert.Equal(t, 0, seq.index)
+	assert.Equal(t, float32(3.5), seq.Value())
+	// Removing the current tween moves on to the start of the next one
 	seq.Remove(0)
 	assert.Equal(t, 1, len(seq.Tweens))
+	assert.Equal(t, 0, seq.index)
+	assert.Equal(t, float32(4), seq.Value())
 	// Out of bound checking
 	seq.Remove(0)
 	assert.Equal(t, 0, len(seq.Tweens))
@@ -336,6 +344,206 @@ func TestSequence_Remove(t *testing.T) {
 	assert.Equal(t, 0, len(seq.Tweens))
 }
 
+func TestSequence_RemoveReverse(t *testing.T) {
+	seq := NewSequence(
+		New(0, 1, 1, ease.Linear),
+		New(1, 2, 1, ease.Linear),
+		New(2, 3, 1, ease.Linear),
+	)
+	seq.Update(1.5)
+	seq.SetReverse(true)
+	seq.Remove(1)
+	assert.Equal(t, 0, seq.index)
+	assert.Equal(t, float32(1), seq.Value())
+	current, _, _ := seq.Update(0.25)
+	assert.Equal(t, float32(0.75), current)
+}
+
+func TestSequence_Insert(t *testing.T) {
+	first, second := New(0, 1, 1, ease.Linear), New(1, 2, 1, ease.Linear)
+	seq := NewSequence(first, second)
+
+	// Inserted at the current index before it starts, it plays next
+	inserted := New(10, 20, 1, ease.Linear)
+	seq.Insert(0, inserted)
+	assert.Equal(t, []*Tween{inserted, first, second}, seq.Tweens)
+	current, _, _ := seq.Update(0.5)
+	assert.Equal(t, float32(15), current)
+
+	// Inserted at the current index once it has started, the playhead stays
+	before := New(-1, -2, 1, ease.Linear)
+	seq.Insert(0, before, before)
+	assert.Equal(t, 2, seq.Index())
+	assert.Equal(t, float32(15), seq.Value())
+	current, _, _ = seq.Update(1)
+	assert.Equal(t, float32(0.5), current)
+
+	after := New(5, 6, 1, ease.Linear)
+	seq.Insert(5, after)
+	seq.Insert(7, after)
+	seq.Insert(-1, after)
+	assert.Equal(t, []*Tween{before, before, inserted, first, second, after}, seq.Tweens)
+	_, _, sequenceComplete := seq.Update(2)
+	assert.False(t, sequenceComplete)
+	assert.Equal(t, float32(5.5), seq.Value())
+}
+
+func TestSequence_InsertReverse(t *testing.T) {
+	first, second := New(0, 1, 1, ease.Linear), New(1, 2, 1, ease.Linear)
+	seq := NewSequence(first, second)
+	seq.PlayBackward()
+	seq.Rewind()
+
+	// Inserted at the current index before it starts, the last inserted Tween
+	// plays next and the current Tween waits for the next loop
+	low, high := New(10, 20, 1, ease.Linear), New(20, 30, 1, ease.Linear)
+	seq.Insert(1, low, high)
+	assert.Equal(t, []*Tween{first, low, high, second}, seq.Tweens)
+	assert.Equal(t, 2, seq.Index())
+	current, _, _ := seq.Update(0.5)
+	assert.Equal(t, float32(25), current)
+	current, _, _ = seq.Update(1)
+	assert.Equal(t, float32(15), current)
+	current, _, isFinished := seq.Update(1.5)
+	assert.Equal(t, float32(0), current)
+	assert.True(t, isFinished)
+}
+
+func TestSequence_Replace(t *testing.T) {
+	seq := NewSequence(New(0, 1, 1, ease.Linear), New(1, 2, 1, ease.Linear))
+	seq.Update(0.5)
+	replacement := New(10, 20, 1, ease.Linear)
+	replacement.Set(0.9)
+	seq.Replace(0, replacement)
+	assert.Equal(t, float32(10), seq.Value())
+	seq.Replace(1, New(20, 30, 1, ease.Linear))
+	seq.Replace(2, replacement)
+	current, _, _ := seq.Update(1.5)
+	assert.Equal(t, float32(25), current)
+	assert.Equal(t, 1, seq.IndexOf(seq.Tweens[1]))
+	assert.Equal(t, 0, seq.IndexOf(replacement))
+	assert.Equal(t, -1, seq.IndexOf(New(0, 1, 1, ease.Linear)))
+}
+
+func TestSequence_Clear(t *testing.T) {
+	seq := NewSequence(New(0, 1, 1, ease.Linear), New(1, 2, 1, ease.Linear))
+	tweens := seq.Tweens
+	seq.Update(1.5)
+	seq.Clear()
+	assert.False(t, seq.HasTweens())
+	assert.Equal(t, 0, seq.Index())
+	assert.Equal(t, []*Tween{nil, nil}, tweens)
+	seq.Add(New(5, 6, 1, ease.Linear))
+	current, _, _ := seq.Update(0.5)
+	assert.Equal(t, float32(5.5), current)
+}
+
+func TestSequence_ClearFinished(t *testing.T) {
+	for _, reverse := range []bool{false, true} {
+		seq := NewSequence(New(0, 1, 1, ease.Linear), New(1, 2, 1, ease.Linear))
+		seq.SetLoopMode(LoopIncremental)
+		seq.SetLoop(2)
+		seq.PlayBackward()
+		if !reverse {
+			seq.PlayForward()
+		}
+		seq.Update(5)
+		seq.Clear()
+		seq.Add(New(5, 6, 1, ease.Linear))
+		current, tweenComplete, sequenceComplete := seq.Update(0.5)
+		assert.Equal(t, float32(5.5), current, "reverse %v", reverse)
+		assert.False(t, tweenComplete)
+		assert.False(t, sequenceComplete)
+		assert.Equal(t, 2, seq.LoopsRemaining())
+		current, _, sequenceComplete = seq.Update(1.5)
+		assert.Equal(t, float32(7), current)
+		assert.True(t, sequenceComplete)
+	}
+}
+
+func TestSequence_Delay(t *testing.T) {
+	seq := NewSequence(New(0, 1, 1, ease.Linear), NewDelay(1), NewBy(1, 1, ease.Linear))
+	current, _, _ := seq.Update(1.5)
+	assert.Equal(t, float32(1), current)
+	current, _, _ = seq.Update(1)
+	assert.Equal(t, float32(1.5), current)
+
+	// Reversed, the delay holds where the tween after it begins
+	seq.SetReverse(true)
+	current, _, _ = seq.Update(1)
+	assert.Equal(t, float32(1), current)
+	current, _, _ = seq.Update(0.5)
+	assert.Equal(t, float32(1), current)
+	current, _, sequenceComplete := seq.Update(0.5)
+	assert.Equal(t, float32(0.5), current)
+	assert.False(t, sequenceComplete)
+
+	// On its own a delay holds its source
+	delay := NewDelay(1)
+	delay.SetSource(func() float32 { return 3 })
+	current, isFinished := delay.Update(0.5)
+	assert.Equal(t, float32(3), current)
+	assert.False(t, isFinished)
+}
+
+func TestSequence_Callback(t *testing.T) {
+	var calls []string
+	callback := func(name string) *Tween {
+		return NewCallback(func() { calls = append(calls, name) })
+	}
+	seq := NewSequence(callback("start"), New(0, 1, 1, ease.Linear), callback("middle"), NewDelay(1), callback("end"))
+	current, _, _ := seq.Update(0.5)
+	assert.Equal(t, float32(0.5), current)
+	assert.Equal(t, []string{"start"}, calls)
+
+	// The overflow carries through the callback into the delay
+	current, _, _ = seq.Update(1)
+	assert.Equal(t, float32(1), current)
+	assert.Equal(t, []string{"start", "middle"}, calls)
+	_, _, sequenceComplete := seq.Update(0.5)
+	assert.True(t, sequenceComplete)
+	assert.Equal(t, []string{"start", "middle", "end"}, calls)
+	seq.Update(1)
+	assert.Equal(t, []string{"start", "middle", "end"}, calls)
+
+	calls = nil
+	seq.Reset()
+	seq.SetLoop(2)
+	seq.Update(5)
+	assert.Equal(t, []string{"start", "middle", "end", "start", "middle", "end"}, calls)
+
+	calls = nil
+	seq.SetLoop(1)
+	seq.Reset()
+	seq.SetIndex(4)
+	seq.SetReverse(true)
+	seq.Update(1.5)
+	assert.Equal(t, []string{"end", "middle"}, calls)
+}
+
+func TestSequence_CallbackYoyo(t *testing.T) {
+	var calls []string
+	callback := func(name string) *Tween {
+		return NewCallback(func() { calls = append(calls, name) })
+	}
+	seq := NewSequence(callback("start"), New(0, 1, 1, ease.Linear), callback("end"))
+	seq.SetYoyo(true)
+	seq.SetLoop(2)
+	_, _, sequenceComplete := seq.Update(4)
+	assert.True(t, sequenceComplete)
+	// Bouncing off either end passes the callback there once
+	assert.Equal(t, []string{"start", "end", "start", "end", "start"}, calls)
+}
+
+func TestSequence_CallbackInfinite(t *testing.T) {
+	count := 0
+	seq := NewSequence(NewCallback(func() { count++ }))
+	seq.SetLoop(-1)
+	_, _, sequenceComplete := seq.Update(1)
+	assert.False(t, sequenceComplete)
+	assert.Equal(t, 1, count)
+}
+
 func TestSequence_Has(t *testing.T) {
 	seq := NewSequence()
 	assert.False(t, seq.HasTweens())
@@ -362,6 +570,30 @@ func TestSequence_SetIndex(t *testing.T) {
 	assert.Equal(t, 2, seq.index)
 }
 
+func TestSequence_Seek(t *testing.T) {
+	seq := NewSequence(New(0, 1, 1, ease.Linear), New(1, 3, 1, ease.Linear))
+	seq.SetLoop(2)
+	seq.Pause()
+	seq.Seek(3.5)
+	assert.Equal(t, float32(2), seq.Value())
+	assert.Equal(t, 1, seq.Index())
+	assert.Equal(t, 1, seq.loopRemaining)
+	seq.Seek(0.5)
+	assert.Equal(t, float32(0.5), seq.Value())
+	assert.Equal(t, 2, seq.loopRemaining)
+}
+
+func TestSequence_SeekYoyo(t *testing.T) {
+	seq := NewSequence(New(0, 1, 1, ease.Linear), New(1, 3, 1, ease.Linear))
+	seq.SetYoyo(true)
+	seq.Update(2.5)
+	seq.Seek(2.5)
+	assert.Equal(t, float32(2), seq.Value())
+	seq.Seek(0.5)
+	assert.Equal(t, float32(0.5), seq.Value())
+	assert.False(t, seq.Reverse())
+}
+
 func TestSequence_RealWorld(t *testing.T) {
 
 	seq := NewSequence(
@@ -401,3 +633,138 @@ func TestSequence_RealWorld(t *testing.T) {
 	assert.False(t, finishedTween)
 	assert.True(t, sequenceFinished)
 }
+
+func TestSequence_Relative(t *testing.T) {
+	seq := NewSequence(
+		New(0, 2, 1, ease.Linear),
+		NewBy(3, 1, ease.Linear),
+		NewFrom(10, 1, ease.Linear),
+	)
+
+	current, _, _ := seq.Update(1.5)
+	assert.Equal(t, float32(3.5), current)
+	current, _, _ = seq.Update(0.75)
+	assert.Equal(t, float32(8.75), current)
+	assert.Equal(t, float32(5), seq.Tweens[2].end)
+	current, _, seqFinished := seq.Update(0.75)
+	assert.Equal(t, float32(5), current)
+	assert.True(t, seqFinished)
+}
+
+func TestSequence_RelativeReverse(t *testing.T) {
+	seq := NewSequence(
+		New(0, 2, 1, ease.Linear),
+		NewBy(3, 1, ease.Linear),
+		NewBy(-1, 1, ease.Linear),
+	)
+	seq.SetLoop(2)
+	seq.SetReverse(true)
+
+	// Wrapping around to the end resolves every tween before it
+	current, _, _ := seq.Update(0.5)
+	assert.Equal(t, float32(4.5), current)
+	current, _, _ = seq.Update(1)
+	assert.Equal(t, float32(3.5), current)
+}
+
+func TestSequence_Velocity(t *testing.T) {
+	seq := NewSequence(
+		New(0, 1, 1, ease.Linear),
+		New(1, 5, 2, ease.InQuad),
+	)
+	seq.Update(0.5)
+	assert.InDelta(t, 1, seq.Velocity(), 1e-5)
+	assert.InDelta(t, 0, seq.Acceleration(), 1e-5)
+	seq.Update(1.5)
+	assert.InDelta(t, 2, seq.Velocity(), 1e-5)
+	assert.InDelta(t, 2, seq.Acceleration(), 1e-5)
+	seq.SetTimeScale(0.5)
+	assert.InDelta(t, 1, seq.Velocity(), 1e-5)
+	assert.InDelta(t, 0.5, seq.Acceleration(), 1e-5)
+	seq.Update(10)
+	assert.Equal(t, float32(0), seq.Velocity())
+}
+
+func TestSequence_OnTweenComplete(t *testing.T) {
+	seq := NewSequence(
+		New(0, 1, 1, ease.Linear),
+		New(1, 2, 1, ease.Linear),
+		New(2, 3, 1, ease.Linear),
+	)
+	seq.SetYoyo(true)
+	var completed []int
+	seq.SetOnTweenComplete(func(index int) {
+		completed = append(completed, index)
+	})
+	seq.Update(0.5)
+	assert.Empty(t, completed)
+	seq.Update(4)
+	assert.Equal(t, []int{0, 1, 2, 2}, completed)
+}
+
+func TestSequence_UpdateAllocs(t *testing.T) {
+	seq := newBenchmarkSequence()
+	allocs := testing.AllocsPerRun(100, func() {
+		seq.Update(0.7)
+	})
+	assert.Equal(t, float64(0), allocs)
+}
+
+func newBenchmarkSequence() *Sequence {
+	seq := NewSequence(
+		New(0, 1, 1, ease.Linear),
+		NewBy(2, 0.5, ease.OutQuad),
+		New(3, 0, 0.25, ease.InOutBack),
+	)
+	seq.SetYoyo(true)
+	seq.SetLoop(-1)
+	seq.SetOnTweenComplete(func(index int) {})
+	return seq
+}
+
+func BenchmarkSequence_Update(b *testing.B) {
+	seq := newBenchmarkSequence()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		seq.Update(0.7)
+	}
+}
+
+func TestSequence_InstantTweens(t *testing.T) {
+	seq := NewSequence(New(0, 1, 1, ease.Linear), New(1, 5, 0, ease.Linear), New(5, 6, 1, ease.Linear))
+	current, tweenComplete, _ := seq.Update(1.5)
+	assert.Equal(t, float32(5.5), current)
+	assert.True(t, tweenComplete)
+	assert.Equal(t, 2, seq.Index())
+
+	seq = NewSequence(New(0, 1, 0, ease.Linear), New(1, 2, 0, ease.Linear))
+	seq.SetLoop(3)
+	current, _, sequenceComplete := seq.Update(1)
+	assert.Equal(t, float32(2), current)
+	assert.True(t, sequenceComplete)
+}
+
+func TestSequence_InstantTweensLoopForever(t *testing.T) {
+	var completed []int
+	seq := NewSequence(New(0, 1, 0, ease.Linear), New(1, 2, 0, ease.Linear))
+	seq.SetLoop(-1)
+	seq.SetOnTweenComplete(func(index int) { completed = append(completed, index) })
+	current, tweenComplete, sequenceComplete := seq.Update(1)
+	assert.Equal(t, float32(2), current)
+	assert.True(t, tweenComplete)
+	assert.False(t, sequenceComplete)
+	assert.Equal(t, []int{0, 1}, completed)
+
+	completed = nil
+	seq.Update(1)
+	assert.Equal(t, []int{0, 1}, completed)
+
+	seq.SetYoyo(true)
+	seq.Reset()
+	completed = nil
+	current, _, sequenceComplete = seq.Update(1)
+	assert.Equal(t, float32(0), current)
+	assert.False(t, sequenceComplete)
+	assert.Equal(t, []int{0, 1, 1, 0}, completed)
+}
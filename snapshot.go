@@ -0,0 +1,432 @@
+package gween
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// snapshotVersion is written at the start of every binary snapshot so that
+// snapshots from incompatible versions are rejected.
+const snapshotVersion byte = 4
+
+// ErrInvalidSnapshot is returned when binary snapshot data cannot be decoded.
+var ErrInvalidSnapshot = errors.New("gween: invalid snapshot")
+
+// TweenState is a snapshot of the playback state of a Tween. Restoring it onto a
+// Tween with the same easing function makes it produce identical output from
+// then on. It can be serialized as JSON or in a compact binary form.
+type TweenState struct {
+	Time      float32 `json:"time"`
+	Overflow  float32 `json:"overflow"`
+	Reverse   bool    `json:"reverse"`
+	Paused    bool    `json:"paused"`
+	TimeScale float32 `json:"timeScale"`
+	// Begin, End and Duration capture values resolved or retargeted while playing.
+	Begin    float32 `json:"begin"`
+	End      float32 `json:"end"`
+	Duration float32 `json:"duration"`
+	Resolved bool    `json:"resolved"`
+	Blend    float32 `json:"blend"`
+	// Loop, LoopRemaining, CompletedLoops, Mode, Returning, Mirrored and Shift
+	// capture looping.
+	Loop           int      `json:"loop"`
+	LoopRemaining  int      `json:"loopRemaining"`
+	CompletedLoops int      `json:"completedLoops"`
+	Mode           LoopMode `json:"mode"`
+	Returning      bool     `json:"returning"`
+	Mirrored       bool     `json:"mirrored"`
+	Shift          float32  `json:"shift"`
+}
+
+// SequenceState is a snapshot of the playback state of a Sequence and all of its
+// Tweens. Restoring it onto a Sequence with the same Tweens makes it produce
+// identical output from then on. It can be serialized as JSON or in a compact
+// binary form.
+type SequenceState struct {
+	Index          int          `json:"index"`
+	Loop           int          `json:"loop"`
+	LoopRemaining  int          `json:"loopRemaining"`
+	Reverse        bool         `json:"reverse"`
+	Yoyo           bool         `json:"yoyo"`
+	Paused         bool         `json:"paused"`
+	TimeScale      float32      `json:"timeScale"`
+	AtStart        bool         `json:"atStart"`
+	Mode           LoopMode     `json:"mode"`
+	Shift          float32      `json:"shift"`
+	CompletedLoops int          `json:"completedLoops"`
+	Turned         bool         `json:"turned"`
+	YoyoLoops      YoyoLoops    `json:"yoyoLoops"`
+	Tweens         []TweenState `json:"tweens"`
+	// Order is the position each Tween had before a LoopShuffle Sequence
+	// reordered them, so that restoring puts them back in the same order. It is
+	// nil if they have not been reordered.
+	Order []int `json:"order,omitempty"`
+}
+
+// sequenceHeader is the fixed size part of a binary SequenceState.
+type sequenceHeader struct {
+	Index          int64
+	Loop           int64
+	LoopRemaining  int64
+	Reverse        bool
+	Yoyo           bool
+	Paused         bool
+	TimeScale      float32
+	AtStart        bool
+	Mode           int64
+	Shift          float32
+	CompletedLoops int64
+	Turned         bool
+	YoyoLoops      YoyoLoops
+	Tweens         uint32
+	// Ordered is whether the Tween records are followed by the order of the Tweens
+	Ordered bool
+}
+
+// tweenRecord is the binary form of a TweenState.
+type tweenRecord struct {
+	Time           float32
+	Overflow       float32
+	Reverse        bool
+	Paused         bool
+	TimeScale      float32
+	Begin          float32
+	End            float32
+	Duration       float32
+	Resolved       bool
+	Blend          float32
+	Loop           int64
+	LoopRemaining  int64
+	CompletedLoops int64
+	Mode           int64
+	Returning      bool
+	Mirrored       bool
+	Shift          float32
+}
+
+// Snapshot captures the current playback state of the Tween.
+func (tween *Tween) Snapshot() TweenState {
+	return TweenState{
+		Time:      tween.time,
+		Overflow:  tween.Overflow,
+		Reverse:   tween.reverse,
+		Paused:    tween.paused,
+		TimeScale: tween.timeScale,
+		Begin:     tween.begin,
+		End:       tween.end,
+		Duration:  tween.duration,
+		Resolved:  tween.resolved,
+		Blend:     tween.blend,
+
+		Loop:           tween.loop,
+		LoopRemaining:  tween.loopRemaining,
+		CompletedLoops: tween.completedLoops,
+		Mode:           tween.loopMode,
+		Returning:      tween.returning,
+		Mirrored:       tween.mirrored,
+		Shift:          tween.shift,
+	}
+}
+
+// Restore sets the playback state of the Tween to a previously captured state.
+func (tween *Tween) Restore(state TweenState) {
+	tween.time = state.Time
+	tween.Overflow = state.Overflow
+	tween.reverse = state.Reverse
+	tween.paused = state.Paused
+	tween.timeScale = state.TimeScale
+	tween.begin = state.Begin
+	tween.end = state.End
+	tween.change = state.End - state.Begin
+	tween.duration = state.Duration
+	tween.resolved = state.Resolved
+	tween.blend = state.Blend
+	tween.loop = state.Loop
+	tween.loopRemaining = state.LoopRemaining
+	tween.completedLoops = state.CompletedLoops
+	tween.loopMode = state.Mode
+	tween.returning = state.Returning
+	tween.mirrored = state.Mirrored
+	tween.shift = state.Shift
+}
+
+// Snapshot captures the current playback state of the Sequence and its Tweens.
+func (seq *Sequence) Snapshot() SequenceState {
+	state := SequenceState{
+		Index:          seq.index,
+		Loop:           seq.loop,
+		LoopRemaining:  seq.loopRemaining,
+		Reverse:        seq.reverse,
+		Yoyo:           seq.yoyo,
+		Paused:         seq.paused,
+		TimeScale:      seq.timeScale,
+		AtStart:        seq.atStart,
+		Mode:           seq.loopMode,
+		Shift:          seq.shift,
+		CompletedLoops: seq.completedLoops,
+		Turned:         seq.turned,
+		YoyoLoops:      seq.yoyoLoops,
+		Tweens:         make([]TweenState, len(seq.Tweens)),
+	}
+	if seq.order != nil && len(seq.order) == len(seq.Tweens) {
+		state.Order = append([]int(nil), seq.order...)
+	}
+	for i, tween := range seq.Tweens {
+		state.Tweens[i] = tween.Snapshot()
+	}
+	return state
+}
+
+// Restore sets the playback state of the Sequence and its Tweens to a previously
+// captured state, putting the Tweens back in the order they were in. It fails if
+// the state was captured from a Sequence with a different number of Tweens.
+func (seq *Sequence) Restore(state SequenceState) error {
+	if len(state.Tweens) != len(seq.Tweens) {
+		return fmt.Errorf("gween: snapshot has %d tweens, sequence has %d", len(state.Tweens), len(seq.Tweens))
+	}
+	if !validOrder(state.Order, len(seq.Tweens)) {
+		return fmt.Errorf("gween: snapshot has an invalid order %v", state.Order)
+	}
+	seq.reorder(state.Order)
+	seq.index = state.Index
+	seq.loop = state.Loop
+	seq.loopRemaining = state.LoopRemaining
+	seq.reverse = state.Reverse
+	seq.yoyo = state.Yoyo
+	seq.paused = state.Paused
+	seq.timeScale = state.TimeScale
+	seq.atStart = state.AtStart
+	seq.loopMode = state.Mode
+	if seq.yoyo && !seq.loopMode.bounces() {
+		// Snapshots from before loop modes only record yoyo
+		seq.loopMode = LoopYoyo
+	}
+	seq.shift = state.Shift
+	seq.completedLoops = state.CompletedLoops
+	seq.turned = state.Turned
+	seq.yoyoLoops = state.YoyoLoops
+	for i, tween := range seq.Tweens {
+		tween.Restore(state.Tweens[i])
+	}
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (state TweenState) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte(snapshotVersion)
+	if err := binary.Write(&buf, binary.LittleEndian, state.record()); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (state *TweenState) UnmarshalBinary(data []byte) error {
+	buf := bytes.NewReader(data)
+	if err := readVersion(buf); err != nil {
+		return err
+	}
+	var record tweenRecord
+	if err := binary.Read(buf, binary.LittleEndian, &record); err != nil || buf.Len() > 0 {
+		return ErrInvalidSnapshot
+	}
+	*state = record.state()
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (state SequenceState) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte(snapshotVersion)
+	header := sequenceHeader{
+		Index:          int64(state.Index),
+		Loop:           int64(state.Loop),
+		LoopRemaining:  int64(state.LoopRemaining),
+		Reverse:        state.Reverse,
+		Yoyo:           state.Yoyo,
+		Paused:         state.Paused,
+		TimeScale:      state.TimeScale,
+		AtStart:        state.AtStart,
+		Mode:           int64(state.Mode),
+		Shift:          state.Shift,
+		CompletedLoops: int64(state.CompletedLoops),
+		Turned:         state.Turned,
+		YoyoLoops:      state.YoyoLoops,
+		Tweens:         uint32(len(state.Tweens)),
+		Ordered:        state.Order != nil,
+	}
+	if state.Order != nil && len(state.Order) != len(state.Tweens) {
+		return nil, fmt.Errorf("gween: snapshot has %d tweens, but an order for %d", len(state.Tweens), len(state.Order))
+	}
+	if err := binary.Write(&buf, binary.LittleEndian, header); err != nil {
+		return nil, err
+	}
+	for _, tween := range state.Tweens {
+		if err := binary.Write(&buf, binary.LittleEndian, tween.record()); err != nil {
+			return nil, err
+		}
+	}
+	for _, i := range state.Order {
+		if err := binary.Write(&buf, binary.LittleEndian, uint32(i)); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (state *SequenceState) UnmarshalBinary(data []byte) error {
+	buf := bytes.NewReader(data)
+	if err := readVersion(buf); err != nil {
+		return err
+	}
+	var header sequenceHeader
+	if err := binary.Read(buf, binary.LittleEndian, &header); err != nil {
+		return ErrInvalidSnapshot
+	}
+	size := int64(binary.Size(tweenRecord{}))
+	if header.Ordered {
+		size += int64(binary.Size(uint32(0)))
+	}
+	if int64(header.Tweens)*size != int64(buf.Len()) {
+		return ErrInvalidSnapshot
+	}
+	records := make([]tweenRecord, header.Tweens)
+	if err := binary.Read(buf, binary.LittleEndian, records); err != nil {
+		return ErrInvalidSnapshot
+	}
+	var order []int
+	if header.Ordered {
+		positions := make([]uint32, header.Tweens)
+		if err := binary.Read(buf, binary.LittleEndian, positions); err != nil {
+			return ErrInvalidSnapshot
+		}
+		order = make([]int, len(positions))
+		for i, position := range positions {
+			order[i] = int(position)
+		}
+	}
+	tweens := make([]TweenState, len(records))
+	for i, record := range records {
+		tweens[i] = record.state()
+	}
+	*state = SequenceState{
+		Index:          int(header.Index),
+		Loop:           int(header.Loop),
+		LoopRemaining:  int(header.LoopRemaining),
+		Reverse:        header.Reverse,
+		Yoyo:           header.Yoyo,
+		Paused:         header.Paused,
+		TimeScale:      header.TimeScale,
+		AtStart:        header.AtStart,
+		Mode:           LoopMode(header.Mode),
+		Shift:          header.Shift,
+		CompletedLoops: int(header.CompletedLoops),
+		Turned:         header.Turned,
+		YoyoLoops:      header.YoyoLoops,
+		Tweens:         tweens,
+		Order:          order,
+	}
+	return nil
+}
+
+func (state TweenState) record() tweenRecord {
+	return tweenRecord{
+		Time:           state.Time,
+		Overflow:       state.Overflow,
+		Reverse:        state.Reverse,
+		Paused:         state.Paused,
+		TimeScale:      state.TimeScale,
+		Begin:          state.Begin,
+		End:            state.End,
+		Duration:       state.Duration,
+		Resolved:       state.Resolved,
+		Blend:          state.Blend,
+		Loop:           int64(state.Loop),
+		LoopRemaining:  int64(state.LoopRemaining),
+		CompletedLoops: int64(state.CompletedLoops),
+		Mode:           int64(state.Mode),
+		Returning:      state.Returning,
+		Mirrored:       state.Mirrored,
+		Shift:          state.Shift,
+	}
+}
+
+func (record tweenRecord) state() TweenState {
+	return TweenState{
+		Time:           record.Time,
+		Overflow:       record.Overflow,
+		Reverse:        record.Reverse,
+		Paused:         record.Paused,
+		TimeScale:      record.TimeScale,
+		Begin:          record.Begin,
+		End:            record.End,
+		Duration:       record.Duration,
+		Resolved:       record.Resolved,
+		Blend:          record.Blend,
+		Loop:           int(record.Loop),
+		LoopRemaining:  int(record.LoopRemaining),
+		CompletedLoops: int(record.CompletedLoops),
+		Mode:           LoopMode(record.Mode),
+		Returning:      record.Returning,
+		Mirrored:       record.Mirrored,
+		Shift:          record.Shift,
+	}
+}
+
+// reorder puts the Tweens in order, as the position each had before shuffling,
+// or back in the order they were added when order is nil.
+func (seq *Sequence) reorder(order []int) {
+	if len(seq.order) != len(seq.Tweens) {
+		seq.order = nil
+	}
+	if seq.order == nil && order == nil {
+		return
+	}
+	added := make([]*Tween, len(seq.Tweens))
+	for i, tween := range seq.Tweens {
+		if seq.order != nil {
+			added[seq.order[i]] = tween
+		} else {
+			added[i] = tween
+		}
+	}
+	for i := range seq.Tweens {
+		if order != nil {
+			seq.Tweens[i] = added[order[i]]
+		} else {
+			seq.Tweens[i] = added[i]
+		}
+	}
+	seq.order = append([]int(nil), order...)
+}
+
+// validOrder returns whether order holds every position below count once,
+// or is nil.
+func validOrder(order []int, count int) bool {
+	if order == nil {
+		return true
+	}
+	if len(order) != count {
+		return false
+	}
+	seen := make([]bool, count)
+	for _, i := range order {
+		if i < 0 || i >= count || seen[i] {
+			return false
+		}
+		seen[i] = true
+	}
+	return true
+}
+
+func readVersion(buf *bytes.Reader) error {
+	version, err := buf.ReadByte()
+	if err != nil || version != snapshotVersion {
+		return ErrInvalidSnapshot
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+package gween
+
+import (
+	"encoding"
+	"encoding/json"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tanema/gween/ease"
+)
+
+var (
+	_ encoding.BinaryMarshaler   = TweenState{}
+	_ encoding.BinaryUnmarshaler = &TweenState{}
+	_ encoding.BinaryMarshaler   = SequenceState{}
+	_ encoding.BinaryUnmarshaler = &SequenceState{}
+)
+
+func newSnapshotSequence() *Sequence {
+	seq := NewSequence(
+		New(0, 1, 1, ease.OutBack),
+		NewBy(3, 1.5, ease.InOutQuad),
+		NewFrom(-2, 1, ease.OutBounce),
+	)
+	seq.SetYoyo(true)
+	seq.SetLoop(3)
+	return seq
+}
+
+func playSequence(seq *Sequence, steps int) []float32 {
+	var values []float32
+	for i := 0; i < steps; i++ {
+		value, _, _ := seq.Update(0.173)
+		values = append(values, value)
+	}
+	return values
+}
+
+func TestTween_Snapshot(t *testing.T) {
+	tween := New(0, 10, 10, ease.InOutCubic)
+	tween.SetTimeScale(1.5)
+	tween.Update(3)
+	tween.Retarget(4, 2)
+	tween.Update(0.5)
+	state := tween.Snapshot()
+	expected, _ := tween.Update(0.25)
+
+	data, err := state.MarshalBinary()
+	require.NoError(t, err)
+	var decoded TweenState
+	require.NoError(t, decoded.UnmarshalBinary(data))
+	assert.Equal(t, state, decoded)
+
+	restored := New(0, 10, 10, ease.InOutCubic)
+	restored.Restore(decoded)
+	current, _ := restored.Update(0.25)
+	assert.Equal(t, expected, current)
+}
+
+func TestSequence_Snapshot(t *testing.T) {
+	seq := newSnapshotSequence()
+	playSequence(seq, 20)
+	state := seq.Snapshot()
+	expected := playSequence(seq, 40)
+
+	jsonData, err := json.Marshal(state)
+	require.NoError(t, err)
+	binaryData, err := state.MarshalBinary()
+	require.NoError(t, err)
+
+	var fromJSON, fromBinary SequenceState
+	require.NoError(t, json.Unmarshal(jsonData, &fromJSON))
+	require.NoError(t, fromBinary.UnmarshalBinary(binaryData))
+	assert.Equal(t, state, fromJSON)
+	assert.Equal(t, state, fromBinary)
+
+	// Rolling back the same sequence replays identically
+	require.NoError(t, seq.Restore(fromBinary))
+	assert.Equal(t, expected, playSequence(seq, 40))
+
+	// So does restoring onto a freshly built copy
+	restored := newSnapshotSequence()
+	require.NoError(t, restored.Restore(fromJSON))
+	assert.Equal(t, expected, playSequence(restored, 40))
+}
+
+func newShuffleSequence(seed int64) *Sequence {
+	seq := NewSequence(
+		New(0, 1, 1, ease.Linear),
+		New(10, 11, 1, ease.Linear),
+		New(20, 21, 1, ease.Linear),
+		New(30, 31, 1, ease.Linear),
+	)
+	seq.SetLoopMode(LoopShuffle)
+	seq.SetLoop(5)
+	seq.SetShuffleRand(rand.New(rand.NewSource(seed)))
+	return seq
+}
+
+func TestSequence_SnapshotShuffle(t *testing.T) {
+	seq := newShuffleSequence(1)
+	seq.Update(9)
+	state := seq.Snapshot()
+	require.Len(t, state.Order, 4)
+	assert.NotEqual(t, []int{0, 1, 2, 3}, state.Order)
+	// Played within the current loop, so that no more shuffling is needed
+	var expected []float32
+	for i := 0; i < 10; i++ {
+		value, _, _ := seq.Update(0.25)
+		expected = append(expected, value)
+	}
+
+	jsonData, err := json.Marshal(state)
+	require.NoError(t, err)
+	binaryData, err := state.MarshalBinary()
+	require.NoError(t, err)
+	var fromJSON, fromBinary SequenceState
+	require.NoError(t, json.Unmarshal(jsonData, &fromJSON))
+	require.NoError(t, fromBinary.UnmarshalBinary(binaryData))
+	assert.Equal(t, state, fromJSON)
+	assert.Equal(t, state, fromBinary)
+
+	for _, restored := range []*Sequence{seq, newShuffleSequence(2)} {
+		require.NoError(t, restored.Restore(fromBinary))
+		for i, tween := range restored.Tweens {
+			assert.Equal(t, float32(10*state.Order[i]), tween.Begin())
+		}
+		var values []float32
+		for i := 0; i < 10; i++ {
+			value, _, _ := restored.Update(0.25)
+			values = append(values, value)
+		}
+		assert.Equal(t, expected, values)
+	}
+
+	// A snapshot from before shuffling puts the Tweens back in the order they
+	// were added
+	require.NoError(t, seq.Restore(newShuffleSequence(1).Snapshot()))
+	for i, tween := range seq.Tweens {
+		assert.Equal(t, float32(10*i), tween.Begin())
+	}
+
+	state.Order = []int{0, 1, 1, 2}
+	assert.EqualError(t, seq.Restore(state), "gween: snapshot has an invalid order [0 1 1 2]")
+	state.Order = []int{0}
+	_, err = state.MarshalBinary()
+	assert.EqualError(t, err, "gween: snapshot has 4 tweens, but an order for 1")
+}
+
+func TestSequence_RestoreMismatch(t *testing.T) {
+	state := newSnapshotSequence().Snapshot()
+	seq := NewSequence(New(0, 1, 1, ease.Linear))
+	assert.EqualError(t, seq.Restore(state), "gween: snapshot has 3 tweens, sequence has 1")
+}
+
+func TestSnapshot_InvalidBinary(t *testing.T) {
+	data, err := newSnapshotSequence().Snapshot().MarshalBinary()
+	require.NoError(t, err)
+
+	var state SequenceState
+	assert.Equal(t, ErrInvalidSnapshot, state.UnmarshalBinary(nil))
+	assert.Equal(t, ErrInvalidSnapshot, state.UnmarshalBinary(data[:len(data)-1]))
+	data[0] = 99
+	assert.Equal(t, ErrInvalidSnapshot, state.UnmarshalBinary(data))
+
+	var tweenState TweenState
+	assert.Equal(t, ErrInvalidSnapshot, tweenState.UnmarshalBinary([]byte{snapshotVersion, 1, 2}))
+}
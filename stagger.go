@@ -0,0 +1,159 @@
+package gween
+
+import (
+	"math"
+
+	"github.com/tanema/gween/ease"
+)
+
+type (
+	// StaggerFrom is where a Stagger cascade starts from.
+	StaggerFrom int
+
+	// Stagger offsets the start of a number of copies of a Tween, for cascades
+	// such as the items of a menu animating in one after the other. Items are
+	// delayed in proportion to their distance from where the cascade starts,
+	// either along a line or, when Columns is set, across a grid.
+	Stagger struct {
+		// Each is the delay between the starts of adjacent items.
+		Each float32
+		// From is where the cascade starts. Defaults to the first item.
+		From StaggerFrom
+		// Easing distributes the delays. The total delay from the first item to
+		// start to the last is still that of a linear cascade, but items are
+		// spread over it by the easing function. Defaults to linear.
+		Easing ease.TweenFunc
+		// Columns lays the items out in a grid with this many columns, filled row
+		// by row, and delays each by its distance from where the cascade starts.
+		Columns int
+	}
+
+	// Range is the values a single staggered item animates between.
+	Range struct {
+		Begin, End float32
+	}
+)
+
+const (
+	// StaggerFromStart starts the cascade at the first item.
+	StaggerFromStart StaggerFrom = iota
+	// StaggerFromCenter starts the cascade at the middle item and spreads outwards.
+	StaggerFromCenter
+	// StaggerFromEnd starts the cascade at the last item.
+	StaggerFromEnd
+)
+
+// Delay returns how long the item at index of count items waits before it starts.
+func (stagger Stagger) Delay(index, count int) float32 {
+	distance, furthest := stagger.distance(index, count), stagger.furthest(count)
+	if stagger.Easing == nil || furthest == 0 {
+		return distance * stagger.Each
+	}
+	return stagger.Easing(distance, 0, furthest*stagger.Each, furthest)
+}
+
+// Group returns a Group of count Sequences, each holding for its delay and then
+// playing a copy of template.
+func (stagger Stagger) Group(template *Tween, count int) *Group {
+	group := &Group{Animations: make([]Animation, 0, count)}
+	for i := 0; i < count; i++ {
+		group.Add(stagger.sequence(template.clone(), i, count))
+	}
+	return group
+}
+
+// GroupRanges returns a Group of Sequences, one for each range, each holding for
+// its delay and then playing a copy of template animating between the range.
+func (stagger Stagger) GroupRanges(template *Tween, ranges ...Range) *Group {
+	group := &Group{Animations: make([]Animation, 0, len(ranges))}
+	for i, r := range ranges {
+		tween := template.clone()
+		tween.begin, tween.end, tween.change = r.Begin, r.End, r.End-r.Begin
+		tween.kind, tween.resolved = KindAbsolute, true
+		group.Add(stagger.sequence(tween, i, len(ranges)))
+	}
+	return group
+}
+
+// sequence returns a Sequence that holds for the delay of the item at index and
+// then plays tween. The tags of tween are moved to the Sequence, so that
+// selecting them in the Group controls the whole item.
+func (stagger Stagger) sequence(tween *Tween, index, count int) *Sequence {
+	seq := NewSequence()
+	if delay := stagger.Delay(index, count); delay > 0 {
+		hold := NewDelay(delay)
+		if tween.resolved {
+			hold.Resolve(tween.begin)
+		} else {
+			hold.source = tween.source
+		}
+		seq.Add(hold)
+	}
+	seq.Add(tween)
+	seq.Tag(tween.tags...)
+	tween.tags = nil
+	return seq
+}
+
+// distance returns how far the item at index is from where the cascade starts,
+// in items.
+func (stagger Stagger) distance(index, count int) float32 {
+	if stagger.Columns <= 0 {
+		switch stagger.From {
+		case StaggerFromCenter:
+			return float32(math.Abs(float64(index) - float64(count-1)/2))
+		case StaggerFromEnd:
+			return float32(count - 1 - index)
+		}
+		return float32(index)
+	}
+	columns := stagger.Columns
+	rows := (count + columns - 1) / columns
+	x, y := float64(index%columns), float64(index/columns)
+	var originX, originY float64
+	switch stagger.From {
+	case StaggerFromCenter:
+		originX, originY = float64(columns-1)/2, float64(rows-1)/2
+	case StaggerFromEnd:
+		// The last item, which is not in the last column when the last row is short
+		originX, originY = float64((count-1)%columns), float64((count-1)/columns)
+	}
+	return float32(math.Hypot(x-originX, y-originY))
+}
+
+// furthest returns the distance of the item furthest from where the cascade
+// starts. Distances only grow away from the start, so along a line that is one
+// of the ends and across a grid one of the corners, where the last row may be
+// shorter than the others.
+func (stagger Stagger) furthest(count int) float32 {
+	if count <= 0 {
+		return 0
+	}
+	corners := []int{0, count - 1}
+	if columns := stagger.Columns; columns > 0 {
+		lastRow := (count - 1) / columns * columns
+		corners = append(corners, lastRow, lastRow-1)
+		if columns-1 < count {
+			corners = append(corners, columns-1)
+		}
+	}
+	var furthest float32
+	for _, index := range corners {
+		if index < 0 {
+			continue
+		}
+		if d := stagger.distance(index, count); d > furthest {
+			furthest = d
+		}
+	}
+	return furthest
+}
+
+// clone returns a copy of the Tween, rewound to its beginning, with its own tags
+// and no id.
+func (tween *Tween) clone() *Tween {
+	clone := *tween
+	clone.labels = labels{tags: append([]string(nil), tween.tags...)}
+	clone.Reset()
+	return &clone
+}
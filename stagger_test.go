@@ -0,0 +1,114 @@
+package gween
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tanema/gween/ease"
+)
+
+func delays(stagger Stagger, count int) []float32 {
+	result := make([]float32, count)
+	for i := range result {
+		result[i] = stagger.Delay(i, count)
+	}
+	return result
+}
+
+func TestStagger_Delay(t *testing.T) {
+	assert.Equal(t, []float32{0, 0.1, 0.2, 0.3, 0.4}, delays(Stagger{Each: 0.1}, 5))
+	assert.Equal(t, []float32{0.4, 0.3, 0.2, 0.1, 0}, delays(Stagger{Each: 0.1, From: StaggerFromEnd}, 5))
+	assert.Equal(t, []float32{1, 0.5, 0, 0.5, 1}, delays(Stagger{Each: 0.5, From: StaggerFromCenter}, 5))
+	assert.Equal(t, []float32{0.75, 0.25, 0.25, 0.75}, delays(Stagger{Each: 0.5, From: StaggerFromCenter}, 4))
+	assert.Equal(t, []float32{0, 0.25, 1, 2.25, 4}, delays(Stagger{Each: 1, Easing: ease.InQuad}, 5))
+	assert.Equal(t, []float32{0}, delays(Stagger{Each: 1, Easing: ease.InQuad}, 1))
+}
+
+func TestStagger_DelayGrid(t *testing.T) {
+	grid := delays(Stagger{Each: 1, Columns: 3}, 6)
+	assert.Equal(t, []float32{0, 1, 2, 1}, grid[:4])
+	assert.InDelta(t, 1.4142, grid[4], 1e-4)
+	assert.InDelta(t, 2.2361, grid[5], 1e-4)
+
+	grid = delays(Stagger{Each: 1, Columns: 3, From: StaggerFromCenter}, 9)
+	assert.Equal(t, float32(0), grid[4])
+	assert.Equal(t, float32(1), grid[1])
+	assert.InDelta(t, 1.4142, grid[0], 1e-4)
+
+	grid = delays(Stagger{Each: 1, Columns: 2, From: StaggerFromEnd}, 4)
+	assert.Equal(t, float32(0), grid[3])
+	assert.Equal(t, float32(1), grid[1])
+
+	// The last row is short, so the cascade starts from the last item rather than
+	// from the empty end of the row
+	grid = delays(Stagger{Each: 1, Columns: 3, From: StaggerFromEnd}, 5)
+	assert.Equal(t, []float32{1, 0}, grid[3:])
+	assert.Equal(t, float32(1), grid[1])
+	assert.InDelta(t, 1.4142, grid[0], 1e-4)
+	assert.InDelta(t, 1.4142, grid[2], 1e-4)
+}
+
+func TestStagger_Furthest(t *testing.T) {
+	for _, from := range []StaggerFrom{StaggerFromStart, StaggerFromCenter, StaggerFromEnd} {
+		for columns := 0; columns <= 5; columns++ {
+			for count := 1; count <= 20; count++ {
+				stagger := Stagger{From: from, Columns: columns}
+				var furthest float32
+				for i := 0; i < count; i++ {
+					if d := stagger.distance(i, count); d > furthest {
+						furthest = d
+					}
+				}
+				assert.Equal(t, furthest, stagger.furthest(count), "%+v of %d", stagger, count)
+			}
+		}
+	}
+}
+
+func TestStagger_Group(t *testing.T) {
+	template := New(0, 10, 1, ease.Linear)
+	template.Tag("menu")
+	group := Stagger{Each: 0.5}.Group(template, 3)
+	assert.Equal(t, 3, group.Len())
+	assert.Len(t, group.Find("menu"), 3)
+
+	values := func() []float32 {
+		result := make([]float32, group.Len())
+		for i, animation := range group.Animations {
+			result[i] = animation.Value()
+		}
+		return result
+	}
+	group.Update(0.5)
+	assert.Equal(t, []float32{5, 0, 0}, values())
+	group.Update(0.5)
+	assert.Equal(t, []float32{5, 0}, values())
+	group.Update(0.25)
+	assert.Equal(t, []float32{7.5, 2.5}, values())
+	assert.True(t, group.Update(1))
+	assert.Equal(t, float32(0), template.Value())
+}
+
+func TestStagger_GroupRanges(t *testing.T) {
+	template := NewBy(100, 1, ease.Linear)
+	group := Stagger{Each: 1, From: StaggerFromEnd}.GroupRanges(template, Range{0, 10}, Range{20, 10})
+	group.Update(0.5)
+	assert.Equal(t, float32(0), group.Animations[0].Value())
+	assert.Equal(t, float32(15), group.Animations[1].Value())
+	// The second item completes and is removed
+	group.Update(1)
+	assert.Equal(t, float32(5), group.Animations[0].Value())
+}
+
+func TestStagger_GroupRelative(t *testing.T) {
+	template := NewBy(10, 1, ease.Linear)
+	template.SetSource(func() float32 { return 5 })
+	group := Stagger{Each: 1}.Group(template, 2)
+	group.Update(0.5)
+	assert.Equal(t, float32(10), group.Animations[0].Value())
+	assert.Equal(t, float32(5), group.Animations[1].Value())
+	// The first item completes and is removed
+	group.Update(1)
+	assert.Equal(t, float32(10), group.Animations[0].Value())
+}